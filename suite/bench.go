@@ -0,0 +1,226 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// RunBenchmarks runs all of the benchmarks attached to a suite, which are its
+// methods starting with "Benchmark". Like [Run] does for tests, it runs the
+// suite setup and teardown hooks once, and each benchmark as a sub-benchmark
+// with a fresh instance of the suite sharing the global data.
+//
+// The body of a benchmark is run once per round, with a growing b.N, so the
+// SetupTest and TearDownTest hooks are run around each round, with the timer
+// reset after the setup and stopped before the teardown. All the rounds share
+// the instance of the suite of the benchmark. The current *testing.B is
+// available through [Suite.B].
+//
+//	func BenchmarkEntryPoint(b *testing.B) {
+//		suite.RunBenchmarks[MySuite, GlobalData](b)
+//	}
+//
+//	func (s *MySuite) BenchmarkQuery() {
+//		s.B().ReportAllocs()
+//		for i := 0; i < s.B().N; i++ {
+//			s.G().DB.Query(...)
+//		}
+//	}
+func RunBenchmarks[T any, G any](testingB *testing.B) {
+	flag.Parse()
+
+	s := &Suite[T, G]{}
+	suite := new(T)
+	s.setB(testingB)
+	s.setG(new(G))
+	s.setS(suite)
+	s.setP(nil)
+
+	// This catches panics in the suite setup and fails the benchmark.
+	defer recoverAndFailOnPanic(s)
+
+	if err := setField(s.suite, "Suite", s); err != nil {
+		panic("make sure that your test suite embeds `*suite.Suite`")
+	}
+
+	methodFinder := reflect.TypeOf(suite)
+	s.info = TestInfo{SuiteName: methodFinder.Elem().Name(), Name: testingB.Name()}
+
+	var methods []reflect.Method
+	f, err := methodFilter("Benchmark")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+	for i := 0; i < methodFinder.NumMethod(); i++ {
+		method := methodFinder.Method(i)
+		if f(method.Name) {
+			methods = append(methods, method)
+		}
+	}
+
+	if len(methods) == 0 {
+		testingB.Log("warning: no benchmarks to run")
+		return
+	}
+
+	// The errors reported by the teardown hooks of the suite are reported
+	// together once all of them have run.
+	s.Cleanup(func() { s.teardown.report(s.tb) })
+
+	// The suite can be skipped before it is even setup.
+	var skip *SkipInfo
+	if shouldSkipSuite, ok := any(suite).(ShouldSkipSuite); ok {
+		if ok, reason := shouldSkipSuite.ShouldSkip(); ok {
+			skip = &SkipInfo{Reason: reason, Condition: "ShouldSkip"}
+		}
+	}
+
+	if skip == nil {
+		var setupErr error
+		skip, setupErr = s.setupSuite()
+		s.registerTearDownSuite()
+		s.failOnSetupError(PhaseSetupSuite, setupErr)
+	}
+	if skip != nil {
+		s.skip = skip
+		testingB.Skip(skip.Reason)
+	}
+
+	for _, method := range methods {
+		method := method
+		var (
+			newS     *Suite[T, G]
+			newSuite *T
+		)
+		testingB.Run(method.Name, func(testingB *testing.B) {
+			// The function is called once per round, with the same *testing.B.
+			// The benchmark gets a fresh instance of [Suite] in the first one.
+			// The global data is passed through to all new instances.
+			if newS == nil {
+				newS = &Suite[T, G]{}
+				newSuite = new(T)
+				newS.setB(testingB)
+				newS.setG(s.G())
+				newS.setS(newSuite)
+				newS.setP(s.suite)
+				newS.info = newTestInfo(s.info, testingB)
+
+				if err := setField(newS.suite, "Suite", newS); err != nil {
+					panic("make sure that your test suite embeds `*suite.Suite`")
+				}
+			}
+
+			// This catches panics in the benchmark setup and fails the benchmark.
+			defer recoverAndFailOnPanic(newS)
+
+			newS.benchmark(PhaseSetupTest, func() error {
+				if setupTestSuite, ok := any(newSuite).(SetupTestSuite); ok {
+					setupTestSuite.SetupTest()
+				}
+				if setupTestSuiteE, ok := any(newSuite).(SetupTestSuiteE); ok {
+					return setupTestSuiteE.SetupTestE()
+				}
+				return nil
+			}, func() {
+				if tearDownTestSuiteE, ok := any(newSuite).(TearDownTestSuiteE); ok {
+					newS.runTeardown(PhaseTearDownTest, tearDownTestSuiteE.TearDownTestE)
+				}
+				if tearDownTestSuite, ok := any(newSuite).(TearDownTestSuite); ok {
+					newS.runTeardown(PhaseTearDownTest, func() error {
+						tearDownTestSuite.TearDownTest()
+						return nil
+					})
+				}
+			}, func() {
+				method.Func.Call([]reflect.Value{reflect.ValueOf(newSuite)})
+			})
+		})
+	}
+}
+
+// RunB provides suite functionality around sub-benchmarks, like [Suite.Run]
+// does around subtests. It should be called in place of
+// b.Run(name, func(b *testing.B)) in benchmarks of the suite. The
+// sub-benchmark gets a fresh instance of the suite, shared by all of its
+// rounds, with the SetupSubTest and TearDownSubTest hooks run around each
+// round.
+func (s *Suite[T, G]) RunB(name string, bench func(suite *T)) bool {
+	if s.B() == nil {
+		s.tb.Helper()
+		s.tb.Fatalf("RunB requires a *testing.B, use Run in tests")
+		return false
+	}
+
+	var (
+		newS     *Suite[T, G]
+		newSuite *T
+	)
+	return s.B().Run(name, func(testingB *testing.B) {
+		// The function is called once per round, with the same *testing.B.
+		// The sub-benchmark gets a fresh instance of Suite in the first one.
+		// The global data is passed through to all new instances.
+		if newS == nil {
+			newS = &Suite[T, G]{}
+			newS.setB(testingB)
+			newS.setG(s.G())
+			newS.setP(s.suite)
+			newS.info = newTestInfo(s.info, testingB)
+
+			newSuite = s.newChild()
+			newS.setS(newSuite)
+
+			if err := setField(newS.suite, "Suite", newS); err != nil {
+				panic("make sure that your test suite embeds `*suite.Suite`")
+			}
+		}
+
+		// This catches panics in the sub-benchmark setup and fails it.
+		defer recoverAndFailOnPanic(newS)
+
+		newS.benchmark(PhaseSetupSubTest, func() error {
+			if setupSubTest, ok := any(newSuite).(SetupSubTest); ok {
+				setupSubTest.SetupSubTest()
+			}
+			if setupSubTestE, ok := any(newSuite).(SetupSubTestE); ok {
+				return setupSubTestE.SetupSubTestE()
+			}
+			return nil
+		}, func() {
+			if tearDownSubTestE, ok := any(newSuite).(TearDownSubTestE); ok {
+				newS.runTeardown(PhaseTearDownSubTest, tearDownSubTestE.TearDownSubTestE)
+			}
+			if tearDownSubTest, ok := any(newSuite).(TearDownSubTest); ok {
+				newS.runTeardown(PhaseTearDownSubTest, func() error {
+					tearDownSubTest.TearDownSubTest()
+					return nil
+				})
+			}
+		}, func() {
+			bench(newSuite)
+		})
+	})
+}
+
+// benchmark runs one round of a benchmark: setup, then body with the timer
+// reset, then teardown with the timer stopped. Unlike for tests, teardown runs
+// at the end of the round rather than as a cleanup function, since cleanup
+// functions only run once all the rounds are done.
+func (s *Suite[T, G]) benchmark(setupPhase Phase, setup func() error, teardown func(), body func()) {
+	defer s.teardown.report(s.tb)
+
+	s.setPhase(setupPhase)
+	err := setup()
+	defer func() {
+		s.B().StopTimer()
+		teardown()
+	}()
+	s.failOnSetupError(setupPhase, err)
+
+	s.setPhase(PhaseTest)
+	s.B().ResetTimer()
+	body()
+}
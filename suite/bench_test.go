@@ -0,0 +1,148 @@
+package suite_test
+
+import (
+	"flag"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// benchSuite is intended to test that benchmarks run with the setup and
+// teardown hooks of the suite.
+type benchSuite struct {
+	*suite.Suite[benchSuite, benchSuiteGlobalData]
+
+	PerTestData string
+}
+
+type benchSuiteGlobalData struct {
+	Fixture string
+}
+
+var (
+	benchCalls    []string
+	benchCallsMu  sync.Mutex
+	benchAttempts []int // the attempt of each round of BenchmarkOne
+)
+
+func (s *benchSuite) record(call string) {
+	benchCallsMu.Lock()
+	defer benchCallsMu.Unlock()
+	benchCalls = append(benchCalls, call)
+}
+
+func (s *benchSuite) SetupSuite() {
+	s.record("SetupSuite")
+	s.G().Fixture = "fixture"
+}
+
+func (s *benchSuite) TearDownSuite() {
+	s.record("TearDownSuite")
+}
+
+func (s *benchSuite) SetupTest() {
+	s.record("SetupTest")
+	s.PerTestData = s.Name()
+}
+
+func (s *benchSuite) TearDownTest() {
+	s.record("TearDownTest")
+}
+
+func (s *benchSuite) SetupSubTest() {
+	s.record("SetupSubTest")
+}
+
+func (s *benchSuite) TearDownSubTest() {
+	s.record("TearDownSubTest")
+}
+
+func (s *benchSuite) BenchmarkOne() {
+	s.Nil(s.T())
+	s.Same(s.B(), s.TB())
+	s.Equal("fixture", s.G().Fixture)
+	s.Equal(s.Name(), s.PerTestData)
+	benchAttempts = append(benchAttempts, s.Info().Attempt)
+	s.B().ReportAllocs()
+	for i := 0; i < s.B().N; i++ {
+		_ = make([]byte, 8)
+	}
+	s.record("BenchmarkOne")
+}
+
+func (s *benchSuite) BenchmarkSub() {
+	s.RunB("sub", func(s *benchSuite) {
+		s.Equal(s.Parent().Name(), s.Parent().PerTestData)
+		for i := 0; i < s.B().N; i++ {
+		}
+		s.record("BenchmarkSub/sub")
+	})
+}
+
+func (s *benchSuite) TestNotABenchmark() {
+	s.record("TestNotABenchmark")
+}
+
+func TestSuiteBenchmarks(t *testing.T) {
+	benchtime := flag.Lookup("test.benchtime").Value.String()
+	require.NoError(t, flag.Set("test.benchtime", "3x"))
+	defer func() { require.NoError(t, flag.Set("test.benchtime", benchtime)) }()
+
+	benchCalls, benchAttempts = nil, nil
+	var failed bool
+	testing.Benchmark(func(b *testing.B) {
+		suite.RunBenchmarks[benchSuite, benchSuiteGlobalData](b)
+		failed = b.Failed()
+	})
+	assert.False(t, failed)
+
+	// Each benchmark is run for one round with b.N == 1, then for another one
+	// with b.N == 3, except for those with sub-benchmarks.
+	assert.Equal(t, []string{
+		"SetupSuite",
+		"SetupTest", "BenchmarkOne", "TearDownTest",
+		"SetupTest", "BenchmarkOne", "TearDownTest",
+		"SetupTest",
+		"SetupSubTest", "BenchmarkSub/sub", "TearDownSubTest",
+		"SetupSubTest", "BenchmarkSub/sub", "TearDownSubTest",
+		"TearDownTest",
+		"TearDownSuite",
+	}, benchCalls)
+	assert.NotContains(t, benchCalls, "TestNotABenchmark")
+
+	// All the rounds are the same attempt of the benchmark.
+	require.Len(t, benchAttempts, 2)
+	assert.Equal(t, benchAttempts[0], benchAttempts[1])
+}
+
+// skippedBenchSuite is intended to test that a skipped suite of benchmarks
+// isn't setup.
+type skippedBenchSuite struct {
+	*suite.Suite[skippedBenchSuite, struct{}]
+}
+
+func (s *skippedBenchSuite) ShouldSkip() (bool, string) {
+	return true, "not today"
+}
+
+func (s *skippedBenchSuite) SetupSuite() {
+	s.Fail("SetupSuite ran although ShouldSkip returned true")
+}
+
+func (s *skippedBenchSuite) BenchmarkOne() {
+	s.Fail("BenchmarkOne ran although ShouldSkip returned true")
+}
+
+func TestSuiteBenchmarksShouldSkip(t *testing.T) {
+	var failed, skipped bool
+	testing.Benchmark(func(b *testing.B) {
+		defer func() { failed, skipped = b.Failed(), b.Skipped() }()
+		suite.RunBenchmarks[skippedBenchSuite, struct{}](b)
+	})
+	assert.False(t, failed)
+	assert.True(t, skipped)
+}
@@ -0,0 +1,196 @@
+package suite
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// stdoutMu serializes the examples, since they all capture os.Stdout.
+var stdoutMu sync.Mutex
+
+// exampleFiles caches the source files of the examples, parsed to read their
+// expected outputs, by file.
+var exampleFiles = struct {
+	sync.Mutex
+	files map[string]parsedFile
+	fset  *token.FileSet
+}{files: map[string]parsedFile{}, fset: token.NewFileSet()}
+
+type parsedFile struct {
+	f   *ast.File
+	err error
+}
+
+// outputPrefix matches the comment giving the expected output of an example,
+// like in the go doc package.
+var outputPrefix = regexp.MustCompile(`(?i)^[[:space:]]*(unordered )?output:`)
+
+// runExample runs an example method of the suite, capturing what it writes to
+// os.Stdout, and fails the test if it doesn't match its expected output. The
+// expected output is the one returned by the ExpectedOutput method of the
+// suite, or else the one given by the "// Output:" comment at the end of the
+// example. If there is none, the example is run without checking its output.
+// The example fails if its source file can't be read, since it may have one.
+func (s *Suite[T, G]) runExample(method reflect.Method) {
+	s.tb.Helper()
+
+	want, unordered, ok := "", false, false
+	if expectedOutput, isExpected := any(s.suite).(ExpectedOutput); isExpected {
+		want, ok = expectedOutput.ExpectedOutput(method.Name)
+	}
+	var err error
+	if !ok {
+		want, unordered, ok, err = exampleOutput(method)
+	}
+
+	var finished bool
+	got := captureStdout(func() {
+		method.Func.Call([]reflect.Value{reflect.ValueOf(s.suite)})
+		finished = true
+	})
+	if err != nil {
+		s.tb.Errorf("%s: can't read the expected output: %v", exampleName(method), err)
+		return
+	}
+	// Like in a test, the output isn't checked if the example stopped early,
+	// e.g. because of a failed require assertion.
+	if !ok || !finished {
+		return
+	}
+
+	got, want = strings.TrimSpace(got), strings.TrimSpace(want)
+	if unordered {
+		got, want = sortLines(got), sortLines(want)
+	}
+	if got != want {
+		s.tb.Errorf("%s: got:\n%s\nwant:\n%s", exampleName(method), got, want)
+	}
+}
+
+// exampleName names an example method in its failures, with its location
+// since they are reported by the suite rather than by the example.
+func exampleName(method reflect.Method) string {
+	fn := runtime.FuncForPC(method.Func.Pointer())
+	if fn == nil {
+		return method.Name
+	}
+	file, line := fn.FileLine(fn.Entry())
+	return fmt.Sprintf("%s (%s:%d)", method.Name, filepath.Base(file), line)
+}
+
+// captureStdout returns what f writes to os.Stdout. os.Stdout is restored even
+// if f panics or calls runtime.Goexit.
+func captureStdout(f func()) string {
+	stdoutMu.Lock()
+	defer stdoutMu.Unlock()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic("suite: can't capture the output of the example: " + err.Error())
+	}
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = io.Copy(&out, r)
+		r.Close()
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	func() {
+		defer func() {
+			os.Stdout = stdout
+			w.Close()
+			<-done
+		}()
+		f()
+	}()
+	return out.String()
+}
+
+// exampleOutput returns the expected output of an example method given by the
+// "// Output:" or "// Unordered output:" comment at the end of its body, read
+// from its source file.
+func exampleOutput(method reflect.Method) (output string, unordered, ok bool, err error) {
+	fn := runtime.FuncForPC(method.Func.Pointer())
+	if fn == nil {
+		return "", false, false, nil
+	}
+	file, line := fn.FileLine(fn.Entry())
+	if !strings.HasSuffix(file, ".go") {
+		return "", false, false, nil
+	}
+
+	exampleFiles.Lock()
+	defer exampleFiles.Unlock()
+	f, err := parseExampleFile(file)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	fset := exampleFiles.fset
+	for _, decl := range f.Decls {
+		funcDecl, isFunc := decl.(*ast.FuncDecl)
+		if !isFunc || funcDecl.Recv == nil || funcDecl.Body == nil || funcDecl.Name.Name != method.Name ||
+			line < fset.Position(funcDecl.Pos()).Line || line > fset.Position(funcDecl.End()).Line {
+			continue
+		}
+
+		// The expected output is given by the last comment of the body.
+		var last *ast.CommentGroup
+		for _, comment := range f.Comments {
+			if comment.Pos() > funcDecl.Body.Lbrace && comment.End() < funcDecl.Body.Rbrace {
+				last = comment
+			}
+		}
+		if last == nil {
+			return "", false, false, nil
+		}
+		text := last.Text()
+		loc := outputPrefix.FindStringSubmatchIndex(text)
+		if loc == nil {
+			return "", false, false, nil
+		}
+		return text[loc[1]:], loc[2] != -1, true, nil
+	}
+	return "", false, false, nil
+}
+
+// parseExampleFile parses the source file of examples, as recorded in the
+// test binary, or returns why it can't be. With -trimpath, the recorded file
+// is relative to the module, so it is looked for in the working directory,
+// which is the directory of the package run by go test. It must be called
+// with exampleFiles held.
+func parseExampleFile(file string) (*ast.File, error) {
+	parsed, ok := exampleFiles.files[file]
+	if !ok {
+		path := file
+		if _, err := os.Stat(path); err != nil && !filepath.IsAbs(path) {
+			path = filepath.Base(path)
+		}
+		parsed.f, parsed.err = parser.ParseFile(exampleFiles.fset, path, nil, parser.ParseComments)
+		exampleFiles.files[file] = parsed
+	}
+	return parsed.f, parsed.err
+}
+
+// sortLines sorts the lines of output, for the examples whose output is
+// unordered.
+func sortLines(output string) string {
+	lines := strings.Split(output, "\n")
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package suite_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// exampleSuite is intended to test that the output of the examples of a suite
+// is checked against the expected output.
+type exampleSuite struct {
+	*suite.Suite[exampleSuite, exampleSuiteGlobalData]
+}
+
+type exampleSuiteGlobalData struct {
+	greeting string
+}
+
+func (s *exampleSuite) SetupSuite() {
+	s.G().greeting = "hello"
+}
+
+func (s *exampleSuite) ExpectedOutput(example string) (string, bool) {
+	if example == "ExampleExpectedOutput" {
+		return "hello, expected", true
+	}
+	return "", false
+}
+
+func (s *exampleSuite) ExampleHello() {
+	fmt.Println(s.G().greeting + ", gopher")
+	// Output: hello, gopher
+}
+
+func (s *exampleSuite) ExampleLines() {
+	fmt.Println("one")
+	fmt.Println("two")
+	// Output:
+	// one
+	// two
+}
+
+func (s *exampleSuite) ExampleUnordered() {
+	for _, name := range []string{"c", "a", "b"} {
+		fmt.Println(name)
+	}
+	// Unordered output:
+	// a
+	// b
+	// c
+}
+
+func (s *exampleSuite) ExampleExpectedOutput() {
+	fmt.Println(s.G().greeting + ", expected")
+}
+
+func (s *exampleSuite) ExampleNoOutput() {
+	fmt.Println("not checked")
+}
+
+func (s *exampleSuite) ExampleWrongOutput() {
+	fmt.Println(s.G().greeting + ", world")
+	// Output: goodbye, world
+}
+
+func (s *exampleSuite) ExampleRequireFails() {
+	fmt.Println("stopped")
+	s.Require().True(false, "stopped early")
+	// Output: not checked
+}
+
+func TestSuiteExamples(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/exampleSuite",
+		F: func(t *testing.T) {
+			suite.Run[exampleSuite, exampleSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, example := range []string{"ExampleHello", "ExampleLines", "ExampleUnordered", "ExampleExpectedOutput", "ExampleNoOutput"} {
+		assert.NotContains(t, output, "--- FAIL: TestSuiteExamples/exampleSuite/"+example+" ")
+	}
+	assert.Contains(t, output, "--- FAIL: TestSuiteExamples/exampleSuite/ExampleWrongOutput")
+	assert.Regexp(t, `ExampleWrongOutput \(example_test.go:\d+\): got:\n\s+hello, world\n\s+want:\n\s+goodbye, world\n`, output)
+	assert.Contains(t, output, "--- FAIL: TestSuiteExamples/exampleSuite/ExampleRequireFails")
+	assert.Contains(t, output, "stopped early")
+	assert.NotContains(t, output, "not checked")
+}
@@ -0,0 +1,214 @@
+package suite
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Recording is what the body passed to [Suite.ExpectFail] reported.
+type Recording struct {
+	// Errors lists the messages of the failures, in order, e.g. those of
+	// the assertions that failed or of the calls to Fatal.
+	Errors []string
+
+	// Logs lists the messages passed to Log and Logf, and the lines written
+	// to Output, like the records of [Suite.Slog], in order.
+	Logs []string
+
+	// Failed is set if the body failed, and FailedNow if it stopped because
+	// of it, e.g. because of a failed require assertion or a call to Fatal.
+	Failed    bool
+	FailedNow bool
+
+	// Skipped is set if the body skipped the test, with the given reason.
+	Skipped    bool
+	SkipReason string
+}
+
+// recorder is a fake testing.TB that records what is reported to it instead
+// of reporting it to the test. The methods it doesn't override, like TempDir
+// and Setenv, are those of the test.
+type recorder struct {
+	testing.TB
+
+	mu       sync.Mutex
+	rec      Recording
+	cleanups []func()
+}
+
+func (r *recorder) Error(args ...any) {
+	r.error(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.error(fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) error(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rec.Errors = append(r.rec.Errors, message)
+	r.rec.Failed = true
+}
+
+func (r *recorder) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rec.Failed = true
+}
+
+func (r *recorder) FailNow() {
+	r.mu.Lock()
+	r.rec.Failed = true
+	r.rec.FailedNow = true
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *recorder) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rec.Failed
+}
+
+func (r *recorder) Fatal(args ...any) {
+	r.Error(args...)
+	r.FailNow()
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.FailNow()
+}
+
+func (r *recorder) Log(args ...any) {
+	r.log(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (r *recorder) Logf(format string, args ...any) {
+	r.log(fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) log(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rec.Logs = append(r.rec.Logs, message)
+}
+
+// Output returns a writer recording each line written to it like a message
+// passed to Log.
+func (r *recorder) Output() io.Writer {
+	return recorderOutput{r}
+}
+
+type recorderOutput struct {
+	r *recorder
+}
+
+func (w recorderOutput) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimSuffix(string(p), "\n"), "\n") {
+		w.r.log(line)
+	}
+	return len(p), nil
+}
+
+func (r *recorder) Skip(args ...any) {
+	r.skip(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (r *recorder) Skipf(format string, args ...any) {
+	r.skip(fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) SkipNow() {
+	r.skip("")
+}
+
+func (r *recorder) skip(reason string) {
+	r.mu.Lock()
+	r.rec.Skipped = true
+	r.rec.SkipReason = reason
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *recorder) Skipped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rec.Skipped
+}
+
+func (r *recorder) Helper() {}
+
+// Cleanup registers f to be called once the body passed to ExpectFail is done.
+func (r *recorder) Cleanup(f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cleanups = append(r.cleanups, f)
+}
+
+// ExpectFail runs body with a copy of the current suite instance whose
+// failures, logs and skips are recorded instead of being reported to the
+// test, and returns what was recorded. It fails the test if body neither
+// failed nor skipped.
+//
+// This is meant to test custom assertion helpers:
+//
+//	rec := s.ExpectFail(func(s *MySuite) {
+//		s.assertValidUser(User{Name: ""})
+//	})
+//	s.Contains(rec.Errors[0], "empty name")
+//
+// As in a test, body stops running when it calls FailNow (or a function that
+// calls it, like the require assertions) or SkipNow, and the cleanup functions
+// it registers run once it is done. Only the methods of the suite report to the
+// recorder: calling the methods of [Suite.T] directly reports to the test.
+func (s *Suite[T, G]) ExpectFail(body func(s *T)) Recording {
+	s.tb.Helper()
+
+	r := &recorder{TB: s.tb}
+	newS := s.newView(r)
+	newSuite := new(T)
+	*newSuite = *s.suite
+	newS.setS(newSuite)
+	if err := setField(newSuite, "Suite", newS); err != nil {
+		panic("make sure that your test suite embeds `*suite.Suite`")
+	}
+	defer func() { s.snapshotCount = newS.snapshotCount }()
+
+	// Like a test, body runs in its own goroutine so that FailNow and SkipNow
+	// can stop it. A panic is propagated to the test.
+	done := make(chan any, 1)
+	go func() {
+		var panicked any
+		defer func() { done <- panicked }()
+		defer func() {
+			// runtime.Goexit can't be recovered from, so this is nil then.
+			panicked = recover()
+		}()
+		defer func() {
+			r.mu.Lock()
+			cleanups := r.cleanups
+			r.mu.Unlock()
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				cleanups[i]()
+			}
+		}()
+		body(newSuite)
+	}()
+	if panicked := <-done; panicked != nil {
+		panic(panicked)
+	}
+
+	r.mu.Lock()
+	rec := r.rec
+	r.mu.Unlock()
+	if !rec.Failed && !rec.Skipped {
+		s.tb.Errorf("expected the body passed to ExpectFail to fail, but it didn't")
+	}
+	return rec
+}
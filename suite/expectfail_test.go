@@ -0,0 +1,91 @@
+package suite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// expectFailSuite is intended to test that ExpectFail records the failures of
+// custom assertion helpers without failing the test.
+type expectFailSuite struct {
+	*suite.Suite[expectFailSuite, expectFailSuiteGlobalData]
+
+	User string
+}
+
+type expectFailSuiteGlobalData struct{}
+
+func (s *expectFailSuite) SetupTest() {
+	s.User = "gopher"
+}
+
+// assertUser is a custom assertion helper.
+func (s *expectFailSuite) assertUser(name string) {
+	s.Helper()
+	s.Log("checking", name)
+	s.NotEmpty(name, "empty name")
+	s.Require().Equal(s.User, name)
+	s.Log("unreachable on failure")
+}
+
+func (s *expectFailSuite) TestErrors() {
+	var cleanedUp bool
+	rec := s.ExpectFail(func(s *expectFailSuite) {
+		s.Cleanup(func() { cleanedUp = true })
+		s.assertUser("")
+	})
+	s.True(cleanedUp)
+	s.True(rec.Failed)
+	s.True(rec.FailedNow)
+	s.False(rec.Skipped)
+	s.Equal([]string{"checking "}, rec.Logs)
+	s.Require().Len(rec.Errors, 2)
+	s.Contains(rec.Errors[0], "empty name")
+	s.Contains(rec.Errors[1], `expected: "gopher"`)
+	s.False(s.Failed())
+}
+
+func (s *expectFailSuite) TestFatalAndSkip() {
+	rec := s.ExpectFail(func(s *expectFailSuite) {
+		s.Fatalf("fatal %d", 1)
+	})
+	s.Equal([]string{"fatal 1"}, rec.Errors)
+
+	rec = s.ExpectFail(func(s *expectFailSuite) {
+		s.Skip("not now")
+	})
+	s.True(rec.Skipped)
+	s.Equal("not now", rec.SkipReason)
+	s.False(s.Skipped())
+}
+
+func (s *expectFailSuite) TestNoFailure() {
+	rec := s.ExpectFail(func(s *expectFailSuite) {
+		s.assertUser("gopher")
+	})
+	s.False(rec.Failed)
+	s.Equal([]string{"checking gopher", "unreachable on failure"}, rec.Logs)
+}
+
+func TestSuiteExpectFail(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/expectFailSuite",
+		F: func(t *testing.T) {
+			suite.Run[expectFailSuite, expectFailSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NotContains(t, output, "--- FAIL: TestSuiteExpectFail/expectFailSuite/TestErrors")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteExpectFail/expectFailSuite/TestFatalAndSkip")
+	assert.Contains(t, output, "--- FAIL: TestSuiteExpectFail/expectFailSuite/TestNoFailure")
+	assert.Contains(t, output, "expected the body passed to ExpectFail to fail, but it didn't")
+}
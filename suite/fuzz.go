@@ -0,0 +1,142 @@
+package suite
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+// RunFuzz runs the fuzz target of a suite given by method, which is the name
+// of one of its methods starting with "Fuzz". Like [Run] does for tests, it
+// runs the suite setup and teardown hooks around it.
+//
+// The method is called with an instance of the suite whose [Suite.F] is f, and
+// adds the seed corpus with [Suite.Add] and the fuzz function with
+// [Suite.Fuzz]. Each input is run with a fresh instance of the suite sharing
+// the global data, with the SetupTest and TearDownTest hooks run around it.
+//
+//	func FuzzEntryPoint(f *testing.F) {
+//		suite.RunFuzz[MySuite, GlobalData](f, "FuzzParse")
+//	}
+//
+//	func (s *MySuite) FuzzParse() {
+//		s.Add("1 + 2")
+//		s.Fuzz(func(s *MySuite, input string) {
+//			s.G().Parser.Parse(input)
+//		})
+//	}
+func RunFuzz[T any, G any](testingF *testing.F, method string) {
+	flag.Parse()
+
+	s := &Suite[T, G]{}
+	suite := new(T)
+	s.setF(testingF)
+	s.setG(new(G))
+	s.setS(suite)
+	s.setP(nil)
+
+	// This catches panics in the suite setup and fails the fuzz target.
+	defer recoverAndFailOnPanic(s)
+
+	if err := setField(s.suite, "Suite", s); err != nil {
+		panic("make sure that your test suite embeds `*suite.Suite`")
+	}
+
+	fuzzTarget, ok := reflect.TypeOf(suite).MethodByName(method)
+	if !ok || fuzzTarget.Type.NumIn() != 1 || fuzzTarget.Type.NumOut() != 0 {
+		testingF.Fatalf("the suite has no fuzz target method %s()", method)
+	}
+	s.info = TestInfo{SuiteName: reflect.TypeOf(suite).Elem().Name(), Name: testingF.Name()}
+
+	// The errors reported by the teardown hooks of the suite are reported
+	// together once all of them have run.
+	s.Cleanup(func() { s.teardown.report(s.tb) })
+
+	// The suite can be skipped before it is even setup.
+	var skip *SkipInfo
+	if shouldSkipSuite, ok := any(suite).(ShouldSkipSuite); ok {
+		if ok, reason := shouldSkipSuite.ShouldSkip(); ok {
+			skip = &SkipInfo{Reason: reason, Condition: "ShouldSkip"}
+		}
+	}
+
+	if skip == nil {
+		var setupErr error
+		skip, setupErr = s.setupSuite()
+		s.registerTearDownSuite()
+		s.failOnSetupError(PhaseSetupSuite, setupErr)
+	}
+	if skip != nil {
+		s.skip = skip
+		testingF.Skip(skip.Reason)
+	}
+
+	// The fuzz target gets a fresh instance of [Suite].
+	newS := &Suite[T, G]{}
+	newSuite := new(T)
+	newS.setF(testingF)
+	newS.setG(s.G())
+	newS.setS(newSuite)
+	newS.setP(s.suite)
+	newS.info = s.info
+	if err := setField(newS.suite, "Suite", newS); err != nil {
+		panic("make sure that your test suite embeds `*suite.Suite`")
+	}
+
+	fuzzTarget.Func.Call([]reflect.Value{reflect.ValueOf(newSuite)})
+}
+
+// Add adds the arguments to the seed corpus of the fuzz target, see
+// [testing.F.Add]. It can only be called from a fuzz target run by [RunFuzz].
+func (s *Suite[T, G]) Add(args ...any) {
+	s.F().Add(args...)
+}
+
+// Fuzz runs the fuzz function ff, see [testing.F.Fuzz]. It can only be called
+// from a fuzz target run by [RunFuzz].
+//
+// Instead of a *testing.T, the first parameter of ff is a fresh instance of
+// the suite for each input, whose [Suite.T] is the *testing.T of the input.
+// The SetupTest and TearDownTest hooks are run around each call to ff.
+func (s *Suite[T, G]) Fuzz(ff any) {
+	fn := reflect.ValueOf(ff)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() < 1 || fnType.In(0) != reflect.TypeOf((*T)(nil)) || fnType.NumOut() != 0 {
+		panic("suite.Fuzz: the fuzz function must be a func(*T, ...) with no return value, where T is the suite")
+	}
+
+	// The fuzz function passed to [testing.F.Fuzz] takes a *testing.T in place
+	// of the suite.
+	in := []reflect.Type{reflect.TypeOf((*testing.T)(nil))}
+	for i := 1; i < fnType.NumIn(); i++ {
+		in = append(in, fnType.In(i))
+	}
+	wrapper := reflect.MakeFunc(reflect.FuncOf(in, nil, false), func(args []reflect.Value) []reflect.Value {
+		testingT := args[0].Interface().(*testing.T)
+
+		// Each input gets a fresh instance of [Suite].
+		// The global data is passed through to all new instances.
+		newS := &Suite[T, G]{}
+		newSuite := new(T)
+		newS.setT(testingT)
+		newS.setG(s.G())
+		newS.setS(newSuite)
+		newS.setP(s.suite)
+		newS.info = newTestInfo(s.info, testingT)
+
+		// This catches panics in the test setup and fails the input.
+		defer recoverAndFailOnPanic(newS)
+
+		if err := setField(newS.suite, "Suite", newS); err != nil {
+			panic("make sure that your test suite embeds `*suite.Suite`")
+		}
+
+		newS.Cleanup(func() { newS.teardown.report(newS.tb) })
+		newS.setupTest()
+
+		args[0] = reflect.ValueOf(newSuite)
+		fn.Call(args)
+		return nil
+	})
+	s.F().Fuzz(wrapper.Interface())
+}
@@ -0,0 +1,78 @@
+package suite_test
+
+import (
+	"flag"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// fuzzSuite is intended to test that fuzz targets run with the setup and
+// teardown hooks of the suite, and a fresh suite instance for each input.
+type fuzzSuite struct {
+	*suite.Suite[fuzzSuite, fuzzSuiteGlobalData]
+
+	PerTestData string
+}
+
+type fuzzSuiteGlobalData struct {
+	Replacer *strings.Replacer
+	Inputs   int32
+}
+
+func (s *fuzzSuite) SetupSuite() {
+	s.G().Replacer = strings.NewReplacer("a", "b")
+}
+
+func (s *fuzzSuite) TearDownSuite() {
+	// Only the seed corpus is run when not fuzzing.
+	if flag.Lookup("test.fuzz").Value.String() == "" {
+		s.Equal(int32(3), atomic.LoadInt32(&s.G().Inputs))
+	}
+}
+
+func (s *fuzzSuite) SetupTest() {
+	s.Empty(s.PerTestData)
+	s.PerTestData = s.Name()
+}
+
+func (s *fuzzSuite) FuzzReplace() {
+	s.NotNil(s.F())
+	s.Add("abc", 1)
+	s.Add("", 2)
+	s.Add("aaa", 3)
+	s.Fuzz(func(s *fuzzSuite, input string, n int) {
+		atomic.AddInt32(&s.G().Inputs, 1)
+		s.NotNil(s.T())
+		s.Equal(s.Name(), s.PerTestData)
+		s.NotContains(s.G().Replacer.Replace(input), "a")
+	})
+}
+
+func FuzzSuite(f *testing.F) {
+	suite.RunFuzz[fuzzSuite, fuzzSuiteGlobalData](f, "FuzzReplace")
+}
+
+// skippedFuzzSuite is intended to test that a skipped suite of fuzz targets
+// isn't setup.
+type skippedFuzzSuite struct {
+	*suite.Suite[skippedFuzzSuite, struct{}]
+}
+
+func (s *skippedFuzzSuite) ShouldSkip() (bool, string) {
+	return true, "not today"
+}
+
+func (s *skippedFuzzSuite) SetupSuite() {
+	s.Fail("SetupSuite ran although ShouldSkip returned true")
+}
+
+func (s *skippedFuzzSuite) FuzzOne() {
+	s.Fail("FuzzOne ran although ShouldSkip returned true")
+}
+
+func FuzzSuiteShouldSkip(f *testing.F) {
+	suite.RunFuzz[skippedFuzzSuite, struct{}](f, "FuzzOne")
+}
@@ -0,0 +1,213 @@
+package suite
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var update = flag.Bool("testify.update", false, "update the golden files and snapshots of the testify suite instead of comparing against them")
+
+// goldenOwners maps the path of each golden file in use to the name of the
+// test using it, so that two tests running in parallel never share one.
+var (
+	goldenOwners   = map[string]string{}
+	goldenOwnersMu sync.Mutex
+)
+
+// Golden asserts that actual matches the content of the golden file name of
+// the current test, which is stored in
+// testdata/<test name>/<name>.golden, e.g.
+// testdata/TestEntryPoint/TestOne/sub1/response.json.golden. When the tests
+// are run with the -testify.update flag, the golden file is written with
+// actual instead.
+//
+// The way actual is compared depends on its type:
+//   - a string is compared as text.
+//   - a json.RawMessage, or a []byte or string when name ends with ".json",
+//     is compared as normalized JSON (indented, with sorted keys).
+//   - a []byte is compared as binary data.
+//   - any other value is marshaled to normalized JSON.
+//
+// On mismatch, the unified diff between the golden file and actual is shown.
+func (s *Suite[T, G]) Golden(name string, actual any) bool {
+	s.tb.Helper()
+
+	path := goldenPath(s.tb.Name(), name)
+	if owner := claimGolden(path, s.tb.Name()); owner != s.tb.Name() {
+		s.tb.Errorf("golden file %s is already used by %s", path, owner)
+		return false
+	}
+
+	got, binary, err := goldenContent(name, actual)
+	if err != nil {
+		s.tb.Errorf("golden file %s: %v", path, err)
+		return false
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.tb.Errorf("golden file %s: %v", path, err)
+		return false
+	}
+	missing := err != nil
+	if !missing && isJSONGolden(name, actual) {
+		// Compare against the normalized golden file so that formatting
+		// changes made by hand don't cause mismatches.
+		if normalized, err := normalizeJSON(want); err == nil {
+			want = normalized
+		}
+	}
+	if !missing && bytes.Equal(want, got) {
+		return true
+	}
+
+	if *update {
+		if err := writeGolden(path, got); err != nil {
+			s.tb.Errorf("golden file %s: %v", path, err)
+			return false
+		}
+		s.tb.Logf("updated golden file %s", path)
+		return true
+	}
+
+	if missing {
+		s.tb.Errorf("golden file %s does not exist, run the tests with -testify.update to create it", path)
+		return false
+	}
+	s.tb.Errorf("golden file %s does not match, run the tests with -testify.update to update it:\n%s",
+		path, goldenDiff(want, got, binary))
+	return false
+}
+
+var unsafePathChars = regexp.MustCompile(`[^\w.-]`)
+
+// goldenPath returns the path of the golden file name of the test testName.
+func goldenPath(testName, name string) string {
+	elems := []string{"testdata"}
+	for _, elem := range strings.Split(testName, "/") {
+		elems = append(elems, unsafePathChars.ReplaceAllString(elem, "_"))
+	}
+	return filepath.Join(append(elems, name+".golden")...)
+}
+
+// claimGolden records testName as the owner of the golden file path, unless
+// it is already owned, and returns its owner.
+func claimGolden(path, testName string) string {
+	goldenOwnersMu.Lock()
+	defer goldenOwnersMu.Unlock()
+	if owner, ok := goldenOwners[path]; ok {
+		return owner
+	}
+	goldenOwners[path] = testName
+	return testName
+}
+
+func isJSONGolden(name string, actual any) bool {
+	switch actual.(type) {
+	case json.RawMessage:
+		return true
+	case string, []byte:
+		return strings.HasSuffix(name, ".json")
+	default:
+		return true
+	}
+}
+
+// goldenContent returns the content of the golden file name for actual, and
+// whether it is binary.
+func goldenContent(name string, actual any) ([]byte, bool, error) {
+	if !isJSONGolden(name, actual) {
+		switch actual := actual.(type) {
+		case string:
+			return []byte(actual), false, nil
+		case []byte:
+			return actual, true, nil
+		}
+	}
+
+	var data []byte
+	switch actual := actual.(type) {
+	case json.RawMessage:
+		data = actual
+	case string:
+		data = []byte(actual)
+	case []byte:
+		data = actual
+	default:
+		var err error
+		if data, err = json.Marshal(actual); err != nil {
+			return nil, false, err
+		}
+	}
+	data, err := normalizeJSON(data)
+	return data, false, err
+}
+
+// normalizeJSON indents data and sorts the keys of its objects.
+func normalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeGolden atomically writes the golden file path, so that a failed update
+// never leaves it truncated.
+func writeGolden(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// goldenDiff returns the unified diff between the content of a golden file
+// and the actual content. Binary content is compared as a hex dump.
+func goldenDiff(want, got []byte, binary bool) string {
+	a, b := string(want), string(got)
+	if binary {
+		a, b = hex.Dump(want), hex.Dump(got)
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: "golden",
+		ToFile:   "actual",
+		Context:  3,
+	})
+	return diff
+}
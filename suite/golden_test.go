@@ -0,0 +1,124 @@
+package suite_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// goldenSuite is intended to test the comparison of text, JSON and binary
+// data against the golden files in testdata.
+type goldenSuite struct {
+	*suite.Suite[goldenSuite, goldenSuiteGlobalData]
+}
+
+type goldenSuiteGlobalData struct{}
+
+func (s *goldenSuite) TestText() {
+	s.Golden("greeting", "hello\nworld\n")
+}
+
+func (s *goldenSuite) TestJSON() {
+	s.Run("raw", func(s *goldenSuite) {
+		s.Parallel()
+		s.Golden("response", json.RawMessage(`{"name":"gopher","tags":["a","b"],"age":13}`))
+	})
+	s.Run("value", func(s *goldenSuite) {
+		s.Parallel()
+		s.Golden("response", map[string]any{"age": 13, "name": "gopher", "tags": []string{"a", "b"}})
+	})
+	s.Run("bytes", func(s *goldenSuite) {
+		s.Parallel()
+		s.Golden("response.json", []byte(`{"tags": ["a", "b"], "name": "gopher", "age": 13}`))
+	})
+}
+
+func (s *goldenSuite) TestBinary() {
+	s.Golden("data", []byte{0x00, 0x01, 0x02, 0xff})
+}
+
+func TestSuiteGolden(t *testing.T) {
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/goldenSuite",
+		F: func(t *testing.T) {
+			suite.Run[goldenSuite, goldenSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+}
+
+// goldenUpdateSuite is intended to test the creation, update and mismatches
+// of golden files.
+type goldenUpdateSuite struct {
+	*suite.Suite[goldenUpdateSuite, goldenUpdateSuiteGlobalData]
+}
+
+type goldenUpdateSuiteGlobalData struct{}
+
+var goldenUpdateText string
+
+func (s *goldenUpdateSuite) TestText() {
+	s.Golden("text", goldenUpdateText)
+}
+
+func (s *goldenUpdateSuite) TestBinary() {
+	s.Golden("binary", []byte(goldenUpdateText))
+}
+
+func TestSuiteGoldenUpdate(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { require.NoError(t, os.Chdir(wd)) }()
+
+	run := func(update bool) (bool, string) {
+		require.NoError(t, flag.Set("testify.update", "false"))
+		if update {
+			require.NoError(t, flag.Set("testify.update", "true"))
+			defer func() { require.NoError(t, flag.Set("testify.update", "false")) }()
+		}
+
+		capture := StdoutCapture{}
+		capture.StartCapture()
+		ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+			Name: t.Name() + "/goldenUpdateSuite",
+			F: func(t *testing.T) {
+				suite.Run[goldenUpdateSuite, goldenUpdateSuiteGlobalData](t)
+			},
+		}})
+		output, err := capture.StopCapture()
+		require.NoError(t, err)
+		return ok, output
+	}
+	path := filepath.Join("testdata", t.Name(), "goldenUpdateSuite", "TestText", "text.golden")
+
+	goldenUpdateText = "one\ntwo\nthree\n"
+	ok, output := run(false)
+	assert.False(t, ok)
+	assert.Contains(t, output, "golden file "+path+" does not exist, run the tests with -testify.update to create it")
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, goldenUpdateText, string(content))
+
+	goldenUpdateText = "one\n2\nthree\n"
+	ok, output = run(false)
+	assert.False(t, ok)
+	assert.Contains(t, output, "golden file "+path+" does not match, run the tests with -testify.update to update it:")
+	assert.Contains(t, output, "-two\n")
+	assert.Contains(t, output, "+2\n")
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+	ok, _ = run(false)
+	assert.True(t, ok)
+}
@@ -0,0 +1,261 @@
+package suite_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// eachHooksSuite is intended to test that BeforeEach and AfterEach are called
+// for every test and subtest with the right information and result.
+type eachHooksSuite struct {
+	*suite.Suite[eachHooksSuite, eachHooksSuiteGlobalData]
+}
+
+type eachHooksSuiteGlobalData struct{}
+
+type eachHooksCall struct {
+	hook   string
+	info   suite.TestInfo
+	result suite.Result
+}
+
+var (
+	eachHooksCalls   = map[string][]eachHooksCall{}
+	eachHooksCallsMu sync.Mutex
+)
+
+func (s *eachHooksSuite) record(hook string, info suite.TestInfo, result suite.Result) {
+	eachHooksCallsMu.Lock()
+	defer eachHooksCallsMu.Unlock()
+	eachHooksCalls[info.Name] = append(eachHooksCalls[info.Name], eachHooksCall{hook, info, result})
+}
+
+func (s *eachHooksSuite) Tags() map[string][]string {
+	return map[string][]string{"TestPass": {"fast", "db"}}
+}
+
+func (s *eachHooksSuite) BeforeEach(info suite.TestInfo) {
+	s.Equal(info, s.Info())
+	s.record("BeforeEach", info, suite.Result{})
+}
+
+func (s *eachHooksSuite) AfterEach(info suite.TestInfo, result suite.Result) {
+	s.record("AfterEach", info, result)
+}
+
+func (s *eachHooksSuite) TestPass() {
+	s.Run("sub", func(s *eachHooksSuite) {
+		s.Parallel()
+		s.Run("nested", func(s *eachHooksSuite) {})
+	})
+}
+
+func (s *eachHooksSuite) TestFail() {
+	s.Fail("intentional failure")
+}
+
+func (s *eachHooksSuite) TestSkip() {
+	s.Skip("intentional skip")
+}
+
+func TestSuiteEachHooks(t *testing.T) {
+	eachHooksCalls = map[string][]eachHooksCall{}
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/eachHooksSuite",
+		F: func(t *testing.T) {
+			suite.Run[eachHooksSuite, eachHooksSuiteGlobalData](t)
+		},
+	}})
+	assert.False(t, ok)
+
+	root := t.Name() + "/eachHooksSuite"
+	expect := []struct {
+		path    []string
+		tags    []string
+		outcome suite.Outcome
+	}{
+		{[]string{"TestPass"}, []string{"fast", "db"}, suite.OutcomePassed},
+		{[]string{"TestPass", "sub"}, []string{"fast", "db"}, suite.OutcomePassed},
+		{[]string{"TestPass", "sub", "nested"}, []string{"fast", "db"}, suite.OutcomePassed},
+		{[]string{"TestFail"}, nil, suite.OutcomeFailed},
+		{[]string{"TestSkip"}, nil, suite.OutcomeSkipped},
+	}
+	require.Len(t, eachHooksCalls, len(expect))
+	for _, e := range expect {
+		name := root
+		for _, p := range e.path {
+			name += "/" + p
+		}
+
+		// The hooks are called once per run of the test, which happens more
+		// than once with -count=X where X > 1.
+		calls := eachHooksCalls[name]
+		require.NotEmpty(t, calls, name)
+		require.Zero(t, len(calls)%2, name)
+		for i := 0; i < len(calls); i += 2 {
+			assert.Equal(t, "BeforeEach", calls[i].hook)
+			assert.Equal(t, "AfterEach", calls[i+1].hook)
+			for _, call := range calls[i : i+2] {
+				assert.Equal(t, "eachHooksSuite", call.info.SuiteName)
+				assert.Equal(t, name, call.info.Name)
+				assert.Equal(t, e.path, call.info.Path)
+				assert.Equal(t, len(e.path), call.info.Depth)
+				assert.Equal(t, e.tags, call.info.Tags)
+				assert.Equal(t, calls[0].info.Attempt+i/2, call.info.Attempt)
+			}
+			assert.Equal(t, e.outcome, calls[i+1].result.Outcome, name)
+			assert.Positive(t, calls[i+1].result.Duration)
+		}
+	}
+}
+
+// subTestHooksSuite is intended to test the order of the named subtest hooks
+// and the passed state received by AfterSubTest.
+type subTestHooksSuite struct {
+	*suite.Suite[subTestHooksSuite, subTestHooksSuiteGlobalData]
+}
+
+type subTestHooksSuiteGlobalData struct{}
+
+var (
+	subTestHooksCalls   = map[string][]string{}
+	subTestHooksCallsMu sync.Mutex
+)
+
+func (s *subTestHooksSuite) record(subTestName, call string) {
+	subTestHooksCallsMu.Lock()
+	defer subTestHooksCallsMu.Unlock()
+	subTestHooksCalls[subTestName] = append(subTestHooksCalls[subTestName], call)
+}
+
+func (s *subTestHooksSuite) SetupSubTest() {
+	s.record(s.Info().Path[len(s.Info().Path)-1], "SetupSubTest")
+}
+
+func (s *subTestHooksSuite) BeforeSubTest(suiteName, testName, subTestName string) {
+	s.record(subTestName, fmt.Sprintf("BeforeSubTest(%s, %s)", suiteName, testName))
+}
+
+func (s *subTestHooksSuite) AfterSubTest(suiteName, testName, subTestName string, passed bool) {
+	s.record(subTestName, fmt.Sprintf("AfterSubTest(%s, %s, %t)", suiteName, testName, passed))
+}
+
+func (s *subTestHooksSuite) TearDownSubTest() {
+	s.record(s.Info().Path[len(s.Info().Path)-1], "TearDownSubTest")
+}
+
+func (s *subTestHooksSuite) TestOne() {
+	s.Run("pass", func(s *subTestHooksSuite) {
+		s.Parallel()
+		s.record("pass", "SubTest")
+	})
+	s.Run("fail", func(s *subTestHooksSuite) {
+		s.Parallel()
+		s.record("fail", "SubTest")
+		s.Fail("intentional failure")
+	})
+}
+
+func TestSuiteSubTestHooks(t *testing.T) {
+	subTestHooksCalls = map[string][]string{}
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/subTestHooksSuite",
+		F: func(t *testing.T) {
+			suite.Run[subTestHooksSuite, subTestHooksSuiteGlobalData](t)
+		},
+	}})
+	assert.False(t, ok)
+
+	callOrderAssert(t, []string{
+		"SetupSubTest",
+		"BeforeSubTest(subTestHooksSuite, TestOne)",
+		"SubTest",
+		"AfterSubTest(subTestHooksSuite, TestOne, true)",
+		"TearDownSubTest",
+	}, subTestHooksCalls["pass"])
+	callOrderAssert(t, []string{
+		"SetupSubTest",
+		"BeforeSubTest(subTestHooksSuite, TestOne)",
+		"SubTest",
+		"AfterSubTest(subTestHooksSuite, TestOne, false)",
+		"TearDownSubTest",
+	}, subTestHooksCalls["fail"])
+}
+
+// childrenHooksSuite is intended to test that BeforeChildren and AfterChildren
+// run once around all the subtests of a test, and that the subtests can access
+// the data set up by BeforeChildren through their parent.
+type childrenHooksSuite struct {
+	*suite.Suite[childrenHooksSuite, childrenHooksSuiteGlobalData]
+
+	Fixture string
+}
+
+type childrenHooksSuiteGlobalData struct{}
+
+var (
+	childrenHooksCalls   []string
+	childrenHooksCallsMu sync.Mutex
+	childrenHooksCtx     context.Context
+)
+
+func (s *childrenHooksSuite) record(call string) {
+	childrenHooksCallsMu.Lock()
+	defer childrenHooksCallsMu.Unlock()
+	childrenHooksCalls = append(childrenHooksCalls, call)
+}
+
+func (s *childrenHooksSuite) BeforeChildren() {
+	s.record("BeforeChildren")
+	s.Fixture = "expensive fixture of " + s.Name()
+}
+
+func (s *childrenHooksSuite) AfterChildren() {
+	s.record("AfterChildren")
+	s.NoError(s.Context().Err())
+}
+
+func (s *childrenHooksSuite) TearDownTest() {
+	s.record("TearDownTest")
+}
+
+func (s *childrenHooksSuite) TestOne() {
+	childrenHooksCtx = s.Context()
+	for _, v := range []string{"sub1", "sub2"} {
+		s.Run(v, func(s *childrenHooksSuite) {
+			s.Parallel()
+			s.Empty(s.Fixture)
+			s.Equal("expensive fixture of "+s.Parent().Name(), s.Parent().Fixture)
+			s.NoError(s.Parent().Context().Err())
+			s.record("SubTest")
+		})
+	}
+}
+
+func TestSuiteChildrenHooks(t *testing.T) {
+	childrenHooksCalls = nil
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/childrenHooksSuite",
+		F: func(t *testing.T) {
+			suite.Run[childrenHooksSuite, childrenHooksSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+
+	callOrderAssert(t, []string{
+		"BeforeChildren", "SubTest", "SubTest", "AfterChildren", "TearDownTest",
+	}, childrenHooksCalls)
+
+	require.NotNil(t, childrenHooksCtx)
+	assert.ErrorIs(t, childrenHooksCtx.Err(), context.Canceled)
+}
@@ -0,0 +1,129 @@
+package suite
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestInfo describes a test or subtest of a suite.
+type TestInfo struct {
+	// SuiteName is the name of the suite type.
+	SuiteName string
+
+	// Name is the full name of the test, as reported by go test.
+	Name string
+
+	// Path holds the names of the test and of its parent subtests, relative
+	// to the suite. For example, ["TestOne", "sub1"].
+	Path []string
+
+	// Depth is 1 for tests, 2 for their subtests, and so on.
+	Depth int
+
+	// Tags are the tags of the test, as returned by [WithTags]. Subtests
+	// inherit the tags of their parent.
+	Tags []string
+
+	// Attempt is the number of times a test with the same full name has been
+	// run by this process, including this one, e.g. when running with -count.
+	Attempt int
+}
+
+// Outcome is the outcome of a test or subtest.
+type Outcome int
+
+const (
+	OutcomePassed Outcome = iota
+	OutcomeFailed
+	OutcomeSkipped
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomePassed:
+		return "passed"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomeSkipped:
+		return "skipped"
+	}
+	return "unknown"
+}
+
+// Result describes how a test or subtest ran.
+type Result struct {
+	Outcome Outcome
+
+	// Duration is the time taken by the test body, including its subtests.
+	Duration time.Duration
+}
+
+// Info returns the information about the current test or subtest.
+func (s *Suite[T, G]) Info() TestInfo {
+	return s.info
+}
+
+// attempts counts the number of times each test has been run by this process.
+var attempts = struct {
+	sync.Mutex
+	count map[string]int
+}{count: make(map[string]int)}
+
+func nextAttempt(name string) int {
+	attempts.Lock()
+	defer attempts.Unlock()
+	attempts.count[name]++
+	return attempts.count[name]
+}
+
+// newTestInfo returns the information about the test run by tb, which is a
+// subtest of the test described by parent.
+func newTestInfo(parent TestInfo, tb testing.TB) TestInfo {
+	path := make([]string, len(parent.Path), len(parent.Path)+1)
+	copy(path, parent.Path)
+	path = append(path, strings.TrimPrefix(tb.Name(), parent.Name+"/"))
+
+	return TestInfo{
+		SuiteName: parent.SuiteName,
+		Name:      tb.Name(),
+		Path:      path,
+		Depth:     len(path),
+		Tags:      parent.Tags,
+		Attempt:   nextAttempt(tb.Name()),
+	}
+}
+
+// result returns the result of the test, whose body started at start.
+func (s *Suite[T, G]) result(start time.Time) Result {
+	outcome := OutcomePassed
+	switch {
+	case s.Failed():
+		outcome = OutcomeFailed
+	case s.Skipped():
+		outcome = OutcomeSkipped
+	}
+	return Result{Outcome: outcome, Duration: time.Since(start)}
+}
+
+// runEachHooks runs BeforeEach and registers AfterEach for the current test
+// or subtest.
+func (s *Suite[T, G]) runEachHooks() {
+	if beforeEach, ok := any(s.suite).(BeforeEach); ok {
+		beforeEach.BeforeEach(s.Info())
+	}
+
+	// We register [AfterEach] after calling [BeforeEach] because we want
+	// [AfterEach] to run before any cleanup functions registered within
+	// [BeforeEach].
+	if afterEach, ok := any(s.suite).(AfterEach); ok {
+		start := time.Now()
+		s.Cleanup(func() {
+			s.runTeardown(PhaseAfterEach, func() error {
+				afterEach.AfterEach(s.Info(), s.result(start))
+				return nil
+			})
+		})
+	}
+}
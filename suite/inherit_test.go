@@ -0,0 +1,110 @@
+package suite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// inheritSuite is intended to test that subtests start with a shallow copy of
+// the per-test data of their parent when the suite opts into it.
+type inheritSuite struct {
+	*suite.Suite[inheritSuite, inheritSuiteGlobalData]
+
+	PerTestData string
+	Labels      map[string]string
+}
+
+type inheritSuiteGlobalData struct{}
+
+func (s *inheritSuite) InheritSubTest() bool {
+	return true
+}
+
+func (s *inheritSuite) SetupTest() {
+	s.PerTestData = "{" + s.Name() + "}"
+	s.Labels = map[string]string{"test": s.Name()}
+}
+
+func (s *inheritSuite) SetupSubTest() {
+	// The per-test data of the parent is available before SetupSubTest runs.
+	s.Equal("{"+s.Parent().Name()+"}", s.PerTestData)
+	s.PerTestData = "(" + s.Name() + ")"
+}
+
+func (s *inheritSuite) TestOne() {
+	s.Run("sub", func(s *inheritSuite) {
+		s.NotSame(s.Suite, s.Parent().Suite)
+		s.Equal("("+s.Name()+")", s.PerTestData)
+
+		// The copy is shallow.
+		s.Labels["sub"] = s.Name()
+	})
+	s.Equal("{"+s.Name()+"}", s.PerTestData)
+	s.Contains(s.Labels, "sub")
+}
+
+func TestSuiteInheritSubTest(t *testing.T) {
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/inheritSuite",
+		F: func(t *testing.T) {
+			suite.Run[inheritSuite, inheritSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+}
+
+// cloneSuite is intended to test that subtests start with the copy of the
+// per-test data of their parent made by Clone.
+type cloneSuite struct {
+	*suite.Suite[cloneSuite, cloneSuiteGlobalData]
+
+	Labels map[string]string
+}
+
+type cloneSuiteGlobalData struct{}
+
+func (s *cloneSuite) Clone() *cloneSuite {
+	labels := make(map[string]string, len(s.Labels))
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	return &cloneSuite{Labels: labels}
+}
+
+func (s *cloneSuite) SetupTest() {
+	s.Labels = map[string]string{"test": s.Name()}
+}
+
+func (s *cloneSuite) TestOne() {
+	for _, v := range []string{"sub1", "sub2"} {
+		s.Run(v, func(s *cloneSuite) {
+			s.Parallel()
+			s.Equal(s.Parent().Name(), s.Labels["test"])
+
+			// The copy is deep, so this doesn't race with the other subtests.
+			s.Labels["sub"] = s.Name()
+
+			s.Run("nested", func(s *cloneSuite) {
+				s.Equal(s.Parent().Name(), s.Labels["sub"])
+			})
+		})
+	}
+}
+
+func (s *cloneSuite) TearDownTest() {
+	// The subtests are done by now.
+	s.NotContains(s.Labels, "sub")
+}
+
+func TestSuiteCloneSubTest(t *testing.T) {
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/cloneSuite",
+		F: func(t *testing.T) {
+			suite.Run[cloneSuite, cloneSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+}
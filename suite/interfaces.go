@@ -12,6 +12,13 @@ type SetupTestSuite interface {
 	SetupTest()
 }
 
+// ShouldSkipSuite has a ShouldSkip method, which will run before SetupSuite.
+// If it returns true, the suite setup and teardown are skipped and all the
+// tests in the suite are marked as skipped with the returned reason.
+type ShouldSkipSuite interface {
+	ShouldSkip() (bool, string)
+}
+
 // TearDownAllSuite has a TearDownSuite method, which will run after
 // all the tests in the suite have been run.
 type TearDownAllSuite interface {
@@ -36,6 +43,128 @@ type AfterTest interface {
 	AfterTest(suiteName, testName string)
 }
 
+// BeforeSubTest has a function to be executed right before each subtest
+// starts and receives the suite, test and subtest names as input. The
+// subtest name is relative to the test, e.g. "sub1/nested".
+type BeforeSubTest interface {
+	BeforeSubTest(suiteName, testName, subTestName string)
+}
+
+// AfterSubTest has a function to be executed right after each subtest
+// finishes and receives the suite, test and subtest names as input, along
+// with whether the subtest passed.
+type AfterSubTest interface {
+	AfterSubTest(suiteName, testName, subTestName string, passed bool)
+}
+
+// InheritSubTest has an InheritSubTest method. If it returns true, each
+// subtest starts with a shallow copy of the per-test data of its parent,
+// made right before SetupSubTest runs, instead of a zero value.
+type InheritSubTest interface {
+	InheritSubTest() bool
+}
+
+// CloneSubTest has a Clone method, which is called on a test (or subtest)
+// to make the copy of its per-test data that each of its subtests starts
+// with, right before SetupSubTest runs. It takes precedence over
+// InheritSubTest and can be used to make a deep copy. The embedded Suite
+// of the returned value is replaced by the one of the subtest.
+type CloneSubTest[T any] interface {
+	Clone() *T
+}
+
+// BeforeChildren has a BeforeChildren method, which will run on a test
+// (or subtest) right before its first subtest is started with Suite.Run.
+// It can be used to set up per-test data that is expensive to create and
+// is shared by all the subtests, which can access it via Suite.Parent.
+type BeforeChildren interface {
+	BeforeChildren()
+}
+
+// AfterChildren has an AfterChildren method, which will run on a test
+// (or subtest) once all of its subtests started with Suite.Run have
+// finished, even in the case of parallel subtests.
+type AfterChildren interface {
+	AfterChildren()
+}
+
+// BeforeEach has a function to be executed right before each test and
+// subtest starts, after BeforeTest, and receives the information about
+// the test as input.
+type BeforeEach interface {
+	BeforeEach(info TestInfo)
+}
+
+// AfterEach has a function to be executed right after each test and
+// subtest finishes, before AfterTest, and receives the information about
+// the test and its result as input.
+type AfterEach interface {
+	AfterEach(info TestInfo, result Result)
+}
+
+// WithTags has a Tags method, which returns the tags of the tests in
+// the suite, keyed by test name. The tags are passed to the hooks that
+// receive a TestInfo.
+type WithTags interface {
+	Tags() map[string][]string
+}
+
+// ReadOnlyGlobal has a ReadOnlyG method. If it returns true, the global
+// data is considered read-only once SetupSuite has run, and each test is
+// checked, once it and all of its cleanup functions are done, not to have
+// modified it. The modifications made while parallel tests are running are
+// only found once all of them are done, and fail the suite rather than a
+// single test. Only the fields of the global data itself are checked, not
+// the values they point to. Fields of type Shared and Counter, or tagged
+// with `testify:"mutable"`, are allowed to be modified.
+type ReadOnlyGlobal interface {
+	ReadOnlyG() bool
+}
+
+// FreezeGlobal has a FreezeG method. If it returns true, the global data is
+// frozen once SetupSuite has run: like with ReadOnlyGlobal, each test is
+// checked not to have modified it, but the values that the global data
+// points to (through pointers, maps and slices) are checked as well. Fields
+// tagged with `testify:"shallow"`, like connection pools, are only checked
+// not to point to something else.
+type FreezeGlobal interface {
+	FreezeG() bool
+}
+
+// CaptureOutput has a CaptureOutput method. If it returns true, what each
+// test writes to [Suite.Stdout] and [Suite.Stderr] (and logs with
+// [Suite.Logger]) is captured rather than written right away. The output of a
+// test and its subtests is logged by the test once it is done, each line
+// prefixed with the name of the test that wrote it, so it is only shown if it
+// failed or if the tests are run with -v. It is also given to the stats of the
+// suite.
+type CaptureOutput interface {
+	CaptureOutput() bool
+}
+
+// CheckGoroutines has a CheckGoroutines method. If it returns true, each test
+// fails if the goroutines it started, including those started by its setup
+// hooks and subtests, are still running once the test and all of its cleanup
+// functions are done. The goroutines are attributed to the test that started
+// them with pprof labels, so that parallel tests are checked separately. The
+// goroutines started with other labels, e.g. under pprof.Do, escape the check.
+// Known background goroutines can be ignored with [Suite.IgnoreGoroutines].
+type CheckGoroutines interface {
+	CheckGoroutines() bool
+}
+
+// CheckResources has a CheckResources method. If it returns true, each test
+// fails if, once it and all of its cleanup functions are done, it left file
+// descriptors open (where /proc/self/fd is available), changed the working
+// directory, changed environment variables other than with [Suite.Setenv], or
+// left files in the temporary directory other than in [Suite.TempDir]. For the
+// latter, TMPDIR points to a temporary directory of the suite while it runs,
+// where supported. Since these are shared by the whole process, the tests
+// calling [Suite.Parallel] aren't checked.
+type CheckResources interface {
+	CheckResources() bool
+}
+
 // WithStats implements HandleStats, a function that will be executed
 // when a test suite is finished. The stats contain information about
 // the execution of that suite and its tests.
@@ -54,3 +183,67 @@ type SetupSubTest interface {
 type TearDownSubTest interface {
 	TearDownSubTest()
 }
+
+// SetupAllSuiteE has a SetupSuiteE method, which will run before the
+// tests in the suite are run. Unlike SetupSuite, it can return an error,
+// in which case the suite fails (or is skipped if the error wraps ErrSkip)
+// after its teardown hooks have run.
+type SetupAllSuiteE interface {
+	SetupSuiteE() error
+}
+
+// SetupTestSuiteE has a SetupTestE method, which will run before each
+// test in the suite. Unlike SetupTest, it can return an error, in which
+// case the test fails (or is skipped if the error wraps ErrSkip) after
+// its teardown hooks have run.
+type SetupTestSuiteE interface {
+	SetupTestE() error
+}
+
+// SetupSubTestE has a SetupSubTestE method, which will run before each
+// subtest in the suite. Unlike SetupSubTest, it can return an error, in
+// which case the subtest fails (or is skipped if the error wraps ErrSkip)
+// after its teardown hooks have run.
+type SetupSubTestE interface {
+	SetupSubTestE() error
+}
+
+// TearDownAllSuiteE has a TearDownSuiteE method, which will run after
+// all the tests in the suite have been run. Unlike TearDownSuite, it can
+// return an error, which is reported along with the errors of the other
+// teardown hooks of the suite.
+type TearDownAllSuiteE interface {
+	TearDownSuiteE() error
+}
+
+// TearDownTestSuiteE has a TearDownTestE method, which will run after
+// each test in the suite. Unlike TearDownTest, it can return an error,
+// which is reported along with the errors of the other teardown hooks
+// of the test.
+type TearDownTestSuiteE interface {
+	TearDownTestE() error
+}
+
+// TearDownSubTestE has a TearDownSubTestE method, which will run after
+// each subtest in the suite. Unlike TearDownSubTest, it can return an
+// error, which is reported along with the errors of the other teardown
+// hooks of the subtest.
+type TearDownSubTestE interface {
+	TearDownSubTestE() error
+}
+
+// AfterTestE has a function to be executed right after the test
+// finishes and receives the suite and test names as input. Unlike
+// AfterTest, it can return an error, which is reported along with the
+// errors of the other teardown hooks of the test.
+type AfterTestE interface {
+	AfterTestE(suiteName, testName string) error
+}
+
+// ExpectedOutput has an ExpectedOutput method, which returns the expected
+// output of the given example method, e.g. "ExampleHello". If ok is false, the
+// expected output is read from the "// Output:" comment at the end of the
+// example method instead.
+type ExpectedOutput interface {
+	ExpectedOutput(example string) (output string, ok bool)
+}
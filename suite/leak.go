@@ -0,0 +1,163 @@
+package suite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leakLabel is the pprof label set on the goroutine of each test whose
+// goroutines are checked, so that the goroutines it starts, which inherit its
+// labels, can be told apart from those of the other tests.
+const leakLabel = "testify.test"
+
+// leakTimeout is how long the goroutines started by a test are given to exit
+// once it is done.
+var leakTimeout = time.Second
+
+// ignoredGoroutines lists the functions of the goroutines that aren't
+// considered leaked.
+type ignoredGoroutines struct {
+	mu        sync.Mutex
+	functions []string
+}
+
+func (i *ignoredGoroutines) add(functions []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.functions = append(i.functions, functions...)
+}
+
+func (i *ignoredGoroutines) ignores(stack goroutineStack) bool {
+	if i == nil {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, function := range i.functions {
+		for _, frame := range stack.functions {
+			if frame == function {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goroutineStack is a stack shared by count goroutines, as reported by the
+// goroutine profile.
+type goroutineStack struct {
+	count     int
+	functions []string
+	frames    []string // function and location of each frame
+}
+
+// labelledGoroutines returns the stacks of the goroutines labelled with the
+// given value of leakLabel.
+func labelledGoroutines(value string) []goroutineStack {
+	var buf bytes.Buffer
+	_ = pprof.Lookup("goroutine").WriteTo(&buf, 1)
+
+	// With debug=1, each stack is a block of lines: the number of goroutines,
+	// then their labels, if any, then one line per frame.
+	label := "# labels: "
+	want := strconv.Quote(leakLabel) + ":" + strconv.Quote(value)
+	var stacks []goroutineStack
+	for _, block := range strings.Split(buf.String(), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		if len(lines) < 2 || !strings.HasPrefix(lines[1], label) || !strings.Contains(lines[1], want) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.SplitN(lines[0], " ", 2)[0])
+		if err != nil {
+			continue
+		}
+		stack := goroutineStack{count: count}
+		for _, line := range lines[2:] {
+			// e.g. "#\t0x4e125c\tmain.main.func1+0x1c\t/tmp/main.go:12"
+			fields := strings.Fields(strings.TrimPrefix(line, "#"))
+			if len(fields) < 3 {
+				continue
+			}
+			function := fields[1]
+			if i := strings.LastIndex(function, "+0x"); i >= 0 {
+				function = function[:i]
+			}
+			stack.functions = append(stack.functions, function)
+			stack.frames = append(stack.frames, function+"\n\t"+fields[2])
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks
+}
+
+// IgnoreGoroutines adds functions to the goroutines that aren't considered
+// leaked by the check of [CheckGoroutines], e.g. known background goroutines.
+// A goroutine is ignored if one of the functions in its stack is one of
+// functions, given by their full name, e.g. "net/http.(*persistConn).readLoop".
+// When called from SetupSuite, it applies to all the tests of the suite.
+func (s *Suite[T, G]) IgnoreGoroutines(functions ...string) {
+	if s.ignoredGoroutines == nil {
+		s.ignoredGoroutines = &ignoredGoroutines{}
+	}
+	s.ignoredGoroutines.add(functions)
+}
+
+// checkGoroutines labels the goroutine of the test, if the suite checks the
+// goroutines of its tests, so that the goroutines it starts are labelled as
+// well. Once the test and all of its cleanup functions are done, it fails the
+// test if any of them are still running. It should be called before registering
+// the cleanup functions that may stop the goroutines of the test. The
+// goroutines ignored by the suite, given by suite, are not considered leaked.
+//
+// The labels of the goroutine of the test are replaced while the test runs,
+// and cleared once its goroutines are checked. The goroutines started with
+// other labels, e.g. under pprof.Do or after calling pprof.SetGoroutineLabels
+// with a context that doesn't carry the label of the test, escape the check.
+func (s *Suite[T, G]) checkGoroutines(suite *Suite[T, G]) {
+	checkGoroutines, ok := any(s.suite).(CheckGoroutines)
+	if !ok || !checkGoroutines.CheckGoroutines() {
+		return
+	}
+
+	value := fmt.Sprintf("%s#%d", s.Name(), s.info.Attempt)
+	s.Cleanup(func() {
+		// The goroutine of the test is labelled as well.
+		pprof.SetGoroutineLabels(context.Background())
+
+		var leaked []goroutineStack
+		deadline := time.Now().Add(leakTimeout)
+		for wait := time.Millisecond; ; wait *= 2 {
+			leaked = leaked[:0]
+			for _, stack := range labelledGoroutines(value) {
+				if !s.ignoredGoroutines.ignores(stack) && !suite.ignoredGoroutines.ignores(stack) {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			if wait > 100*time.Millisecond {
+				wait = 100 * time.Millisecond
+			}
+			time.Sleep(wait)
+		}
+		if len(leaked) == 0 {
+			return
+		}
+
+		var b strings.Builder
+		count := 0
+		for _, stack := range leaked {
+			count += stack.count
+			fmt.Fprintf(&b, "\n\n%d goroutine(s):\n%s", stack.count, strings.Join(stack.frames, "\n"))
+		}
+		s.tb.Errorf("found %d leaked goroutine(s) started by the test:%s", count, b.String())
+	})
+	pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), pprof.Labels(leakLabel, value)))
+}
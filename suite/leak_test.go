@@ -0,0 +1,92 @@
+package suite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// leakSuite is intended to test that the goroutines leaked by the tests are
+// reported against the test that started them.
+type leakSuite struct {
+	*suite.Suite[leakSuite, leakSuiteGlobalData]
+}
+
+type leakSuiteGlobalData struct {
+	release chan struct{}
+}
+
+func (s *leakSuite) CheckGoroutines() bool {
+	return true
+}
+
+func (s *leakSuite) SetupSuite() {
+	s.G().release = make(chan struct{})
+	s.IgnoreGoroutines("github.com/varunbpatil/testify/suite_test.backgroundWorker")
+
+	// The goroutines started by the suite setup aren't checked.
+	go leakedWorker(s.G().release)
+}
+
+func leakedWorker(release chan struct{}) {
+	<-release
+}
+
+func backgroundWorker(release chan struct{}) {
+	<-release
+}
+
+func (s *leakSuite) TestLeak() {
+	s.Parallel()
+	go leakedWorker(s.G().release)
+}
+
+func (s *leakSuite) TestSubtestLeak() {
+	s.Parallel()
+	s.Run("sub", func(s *leakSuite) {
+		s.Parallel()
+		go leakedWorker(s.G().release)
+	})
+}
+
+func (s *leakSuite) TestStopped() {
+	s.Parallel()
+	stop := make(chan struct{})
+	go leakedWorker(stop)
+	s.Cleanup(func() { close(stop) })
+}
+
+func (s *leakSuite) TestIgnored() {
+	s.Parallel()
+	go backgroundWorker(s.G().release)
+	s.IgnoreGoroutines("github.com/varunbpatil/testify/suite_test.leakedWorker")
+	go leakedWorker(s.G().release)
+}
+
+func (s *leakSuite) TearDownSuite() {
+	close(s.G().release)
+}
+
+func TestSuiteGoroutineLeaks(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/leakSuite",
+		F: func(t *testing.T) {
+			suite.Run[leakSuite, leakSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuiteGoroutineLeaks/leakSuite/TestLeak ")
+	assert.Contains(t, output, "--- FAIL: TestSuiteGoroutineLeaks/leakSuite/TestSubtestLeak ")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteGoroutineLeaks/leakSuite/TestSubtestLeak/sub ")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteGoroutineLeaks/leakSuite/TestStopped ")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteGoroutineLeaks/leakSuite/TestIgnored ")
+	assert.Regexp(t, `found 1 leaked goroutine\(s\) started by the test:\n\s+\n\s+1 goroutine\(s\):\n\s+github.com/varunbpatil/testify/suite_test.leakedWorker\n\s+\S+leak_test.go:\d+`, output)
+}
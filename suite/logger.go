@@ -0,0 +1,216 @@
+//go:build go1.21
+
+package suite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Logger returns a logger writing text records to [Suite.Stdout], so that
+// they are captured along with the rest of the output of the test if the
+// suite captures it (see [CaptureOutput]).
+func (s *Suite[T, G]) Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(s.Stdout(), nil))
+}
+
+// Slog returns a logger whose records are written to the output of the test,
+// like with its Log method, so that they are attributed to the right test even
+// when parallel tests log concurrently, and only shown if the test fails or if
+// the tests are run with -v. Each record has the attributes "test", "phase" and
+// "attempt" (see [TestInfo]), and "source" for where it was logged.
+//
+// It is meant to be handed to the code under test:
+//
+//	server := NewServer(WithLogger(s.Slog()))
+//
+// Records logged once the test and all of its cleanup functions are done are
+// dropped.
+func (s *Suite[T, G]) Slog() *slog.Logger {
+	return slog.New(&tbHandler{
+		log:     logOutput(s.tb),
+		logs:    s.logs,
+		name:    s.Name(),
+		attempt: s.info.Attempt,
+		phase:   s.currentPhase,
+		attrs:   [][]slog.Attr{nil},
+	})
+}
+
+// tbHandler is the [slog.Handler] of [Suite.Slog]. The attributes added with
+// WithAttrs are kept by group, so that the attributes of the test can be added
+// at the top level of each record.
+type tbHandler struct {
+	log     func(line string)
+	logs    *logRecorder
+	name    string
+	attempt int
+	phase   func() Phase
+
+	groups []string
+	attrs  [][]slog.Attr // attrs[i] are the attributes within groups[:i]
+}
+
+func (h *tbHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *tbHandler) Handle(_ context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	record.AddAttrs(
+		slog.String("test", h.name),
+		slog.String("phase", string(h.phase())),
+		slog.Int("attempt", h.attempt),
+	)
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		record.AddAttrs(slog.String("source", fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)))
+	}
+
+	// The attributes of the record are within all the groups, so the groups
+	// are nested starting from the innermost one.
+	attrs := append([]slog.Attr{}, h.attrs[len(h.groups)]...)
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = append(append([]slog.Attr{}, h.attrs[i]...), slog.Attr{Key: h.groups[i], Value: slog.GroupValue(attrs...)})
+	}
+	record.AddAttrs(attrs...)
+
+	h.logs.add(newLogRecord(r.Level, r.Message, attrs))
+
+	var buf bytes.Buffer
+	if err := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}).Handle(context.Background(), record); err != nil {
+		return err
+	}
+	h.logLine(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
+
+// logLine logs a formatted record. The test panics if it is done, in which
+// case the record is dropped.
+func (h *tbHandler) logLine(line string) {
+	defer func() { _ = recover() }()
+	h.log(line)
+}
+
+func (h *tbHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := h.clone()
+	last := len(h2.attrs) - 1
+	h2.attrs[last] = append(append([]slog.Attr{}, h2.attrs[last]...), attrs...)
+	return h2
+}
+
+func (h *tbHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := h.clone()
+	h2.groups = append(h2.groups, name)
+	h2.attrs = append(h2.attrs, nil)
+	return h2
+}
+
+func (h *tbHandler) clone() *tbHandler {
+	h2 := *h
+	h2.groups = append([]string{}, h.groups...)
+	h2.attrs = append([][]slog.Attr{}, h.attrs...)
+	return &h2
+}
+
+// newLogRecord returns the record checked by the log assertions, without the
+// attributes of the test.
+func newLogRecord(level slog.Level, message string, attrs []slog.Attr) logRecord {
+	r := logRecord{level: int(level), levelS: level.String(), message: message, attrs: map[string]string{}}
+	flattenAttrs(r.attrs, "", attrs)
+	return r
+}
+
+// flattenAttrs adds attrs to flat, keyed by their name qualified by their
+// groups, e.g. "request.id".
+func flattenAttrs(flat map[string]string, prefix string, attrs []slog.Attr) {
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+		if value.Kind() != slog.KindGroup {
+			flat[prefix+attr.Key] = value.String()
+			continue
+		}
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+		flattenAttrs(flat, groupPrefix, value.Group())
+	}
+}
+
+// LogMatch matches the records logged with [Suite.Slog] for
+// [Suite.AssertLogSequence], like the arguments of [Suite.AssertLogged].
+type LogMatch struct {
+	Level   slog.Level
+	Message string // regular expression
+	Attrs   []any
+}
+
+func (m LogMatch) matcher() logMatcher {
+	// The attributes are parsed by slog, so they are given like to the
+	// methods of slog.Logger: as key-value pairs or slog.Attr values.
+	record := slog.NewRecord(time.Time{}, m.Level, "", 0)
+	record.Add(m.Attrs...)
+	var attrs []slog.Attr
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	r := newLogRecord(m.Level, "", attrs)
+	return logMatcher{level: r.level, levelS: r.levelS, message: regexp.MustCompile(m.Message), attrs: r.attrs}
+}
+
+// AssertLogged asserts that the test logged a record with [Suite.Slog] at the
+// given level, whose message matches the regular expression msgRegexp and
+// which has the given attributes. The attributes are given like to the
+// methods of slog.Logger, as key-value pairs or slog.Attr values, and are
+// compared by their text, with the attributes within groups named after their
+// group, e.g. "request.id". Records logged by subtests aren't checked.
+//
+//	s.AssertLogged(slog.LevelError, "^request failed", "status", 500)
+func (s *Suite[T, G]) AssertLogged(level slog.Level, msgRegexp string, attrs ...any) bool {
+	s.tb.Helper()
+	return s.assertLogged(true, LogMatch{Level: level, Message: msgRegexp, Attrs: attrs}.matcher())
+}
+
+// AssertNotLogged asserts that the test didn't log any record with
+// [Suite.Slog] matching the arguments, see [Suite.AssertLogged].
+func (s *Suite[T, G]) AssertNotLogged(level slog.Level, msgRegexp string, attrs ...any) bool {
+	s.tb.Helper()
+	return s.assertLogged(false, LogMatch{Level: level, Message: msgRegexp, Attrs: attrs}.matcher())
+}
+
+// AssertLogSequence asserts that the test logged records with [Suite.Slog]
+// matching each of matches, in order. Other records may have been logged in
+// between.
+//
+//	s.AssertLogSequence(
+//		suite.LogMatch{Level: slog.LevelInfo, Message: "^connecting"},
+//		suite.LogMatch{Level: slog.LevelInfo, Message: "^connected", Attrs: []any{"attempts", 1}},
+//	)
+func (s *Suite[T, G]) AssertLogSequence(matches ...LogMatch) bool {
+	s.tb.Helper()
+
+	matchers := make([]logMatcher, len(matches))
+	for i, m := range matches {
+		matchers[i] = m.matcher()
+	}
+	return s.assertLogSequence(matchers)
+}
@@ -0,0 +1,130 @@
+//go:build go1.21
+
+package suite_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// slogSuite is intended to test that the records of the logger returned by
+// Slog are logged by the test, with the attributes of the test.
+type slogSuite struct {
+	*suite.Suite[slogSuite, slogSuiteGlobalData]
+}
+
+type slogSuiteGlobalData struct{}
+
+func (s *slogSuite) SetupTest() {
+	s.Slog().Info("setting up")
+}
+
+func (s *slogSuite) TearDownTest() {
+	s.Slog().Warn("tearing down")
+}
+
+func (s *slogSuite) TestLog() {
+	logger := s.Slog().With("service", "api").WithGroup("request").With("id", 42)
+	logger.Debug("handled", "status", 200)
+
+	s.Run("sub", func(s *slogSuite) {
+		s.Slog().Error("in subtest", slog.Group("user", "name", "gopher"))
+		s.Fail("show the logs of the subtest")
+	})
+	s.Fail("show the logs")
+}
+
+func TestSuiteSlog(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/slogSuite",
+		F: func(t *testing.T) {
+			suite.Run[slogSuite, slogSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	test := `test=TestSuiteSlog/slogSuite/TestLog`
+	assert.Regexp(t, `level=INFO msg="setting up" `+test+` phase=SetupTest attempt=\d+ source=logger_test\.go:\d+\n`, output)
+	assert.Regexp(t, `level=DEBUG msg=handled `+test+` phase=Test attempt=\d+ source=logger_test\.go:\d+ service=api request\.id=42 request\.status=200\n`, output)
+	assert.Regexp(t, `level=ERROR msg="in subtest" `+test+`/sub phase=Test attempt=\d+ source=logger_test\.go:\d+ user\.name=gopher\n`, output)
+	assert.Regexp(t, `level=WARN msg="tearing down" `+test+` phase=TearDownTest attempt=\d+ source=logger_test\.go:\d+\n`, output)
+}
+
+// logAssertSuite is intended to test the assertions on the records logged
+// with Slog.
+type logAssertSuite struct {
+	*suite.Suite[logAssertSuite, logAssertSuiteGlobalData]
+}
+
+type logAssertSuiteGlobalData struct{}
+
+func (s *logAssertSuite) TestAssertions() {
+	s.Parallel()
+	logger := s.Slog()
+	logger.Info("connecting", "host", "db")
+	logger.WithGroup("request").Error("request failed", "status", 500)
+	logger.Info("connected", slog.Int("attempts", 1))
+
+	s.AssertLogged(slog.LevelError, "^request failed$", "request.status", 500)
+	s.AssertLogged(slog.LevelInfo, "connect")
+	s.AssertNotLogged(slog.LevelError, "connect")
+	s.AssertLogSequence(
+		suite.LogMatch{Level: slog.LevelInfo, Message: "^connecting", Attrs: []any{"host", "db"}},
+		suite.LogMatch{Level: slog.LevelInfo, Message: "^connected", Attrs: []any{"attempts", 1}},
+	)
+
+	rec := s.ExpectFail(func(s *logAssertSuite) {
+		s.AssertLogged(slog.LevelInfo, "failed")
+	})
+	s.Contains(rec.Errors[0], `No record logged matching: INFO "failed"`)
+	s.Contains(rec.Errors[0], `ERROR "request failed" request.status=500`)
+
+	rec = s.ExpectFail(func(s *logAssertSuite) {
+		s.AssertNotLogged(slog.LevelInfo, "connected", "attempts", "1")
+	})
+	s.Contains(rec.Errors[0], `Unexpected record logged: INFO "connected" attempts=1`)
+
+	rec = s.ExpectFail(func(s *logAssertSuite) {
+		s.AssertLogSequence(
+			suite.LogMatch{Level: slog.LevelInfo, Message: "^connected"},
+			suite.LogMatch{Level: slog.LevelInfo, Message: "^connecting"},
+		)
+	})
+	s.Contains(rec.Errors[0], `No record logged matching: INFO "^connecting"`)
+
+	rec = s.ExpectFail(func(s *logAssertSuite) {
+		s.Slog().Info("recorded")
+		s.Fail("failed")
+	})
+	s.Require().Len(rec.Logs, 1)
+	s.Contains(rec.Logs[0], `level=INFO msg=recorded`)
+}
+
+func (s *logAssertSuite) TestIsolation() {
+	s.Parallel()
+	s.Slog().Info("isolated")
+	s.AssertNotLogged(slog.LevelInfo, "connect")
+
+	s.Run("sub", func(s *logAssertSuite) {
+		s.AssertNotLogged(slog.LevelInfo, "isolated")
+	})
+}
+
+func TestSuiteLogAssertions(t *testing.T) {
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/logAssertSuite",
+		F: func(t *testing.T) {
+			suite.Run[logAssertSuite, logAssertSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+}
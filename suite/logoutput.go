@@ -0,0 +1,18 @@
+//go:build go1.25
+
+package suite
+
+import (
+	"io"
+	"testing"
+)
+
+// logOutput returns what writes the records of [Suite.Slog] to the output of
+// tb. Since the records have their own "source" attribute, they are written
+// without the location of the call to Log that tb would add.
+func logOutput(tb testing.TB) func(line string) {
+	w := tb.Output()
+	return func(line string) {
+		_, _ = io.WriteString(w, line+"\n")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build go1.21 && !go1.25
+
+package suite
+
+import "testing"
+
+// logOutput returns what writes the records of [Suite.Slog] to the output of
+// tb. Before Go 1.25, the output of a test can only be written with Log,
+// which adds the location of its call.
+func logOutput(tb testing.TB) func(line string) {
+	return func(line string) {
+		tb.Log(line)
+	}
+}
@@ -0,0 +1,30 @@
+//go:build go1.25
+
+package suite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+func TestSuiteSlogOutput(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/slogSuite",
+		F: func(t *testing.T) {
+			suite.Run[slogSuite, slogSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The records aren't attributed to the handler, only to their source.
+	assert.Regexp(t, `\n\s+time=\S+ level=INFO msg="setting up" `, output)
+	assert.NotContains(t, output, "logger.go:")
+}
@@ -0,0 +1,131 @@
+package suite
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// logRecord is a record logged with [Suite.Slog]. The values of the attributes
+// are kept as text, keyed by their name qualified by their groups, e.g.
+// "request.id".
+type logRecord struct {
+	level   int
+	levelS  string
+	message string
+	attrs   map[string]string
+}
+
+func (r logRecord) String() string {
+	keys := make([]string, 0, len(r.attrs))
+	for key := range r.attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", r.levelS, r.message)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%s", key, r.attrs[key])
+	}
+	return b.String()
+}
+
+// logMatcher matches the records logged at a level, whose message matches a
+// regular expression and which have some attributes.
+type logMatcher struct {
+	level   int
+	levelS  string
+	message *regexp.Regexp
+	attrs   map[string]string
+}
+
+func (m logMatcher) match(r logRecord) bool {
+	if r.level != m.level || !m.message.MatchString(r.message) {
+		return false
+	}
+	for key, value := range m.attrs {
+		if v, ok := r.attrs[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (m logMatcher) String() string {
+	return logRecord{levelS: m.levelS, message: m.message.String(), attrs: m.attrs}.String()
+}
+
+// logRecorder records what a test logs with [Suite.Slog], so that it can be
+// checked by the log assertions.
+type logRecorder struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+func (l *logRecorder) add(r logRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, r)
+}
+
+func (l *logRecorder) all() []logRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]logRecord{}, l.records...)
+}
+
+// describeLogs lists the records for the failure messages of the log
+// assertions.
+func describeLogs(records []logRecord) string {
+	if len(records) == 0 {
+		return "no records were logged"
+	}
+	var b strings.Builder
+	b.WriteString("logged records:")
+	for _, r := range records {
+		b.WriteString("\n  " + r.String())
+	}
+	return b.String()
+}
+
+// assertLogged checks that one of the records logged by the test matches m,
+// or none of them if logged is false.
+func (s *Suite[T, G]) assertLogged(logged bool, m logMatcher) bool {
+	s.tb.Helper()
+
+	records := s.logs.all()
+	for _, r := range records {
+		if !m.match(r) {
+			continue
+		}
+		if logged {
+			return true
+		}
+		return s.Fail(fmt.Sprintf("Unexpected record logged: %s\nmatching: %s", r, m))
+	}
+	if !logged {
+		return true
+	}
+	return s.Fail(fmt.Sprintf("No record logged matching: %s\n%s", m, describeLogs(records)))
+}
+
+// assertLogSequence checks that records matching each of the matchers were
+// logged by the test in order, possibly with other records in between.
+func (s *Suite[T, G]) assertLogSequence(matchers []logMatcher) bool {
+	s.tb.Helper()
+
+	records := s.logs.all()
+	next := 0
+	for _, r := range records {
+		if next < len(matchers) && matchers[next].match(r) {
+			next++
+		}
+	}
+	if next == len(matchers) {
+		return true
+	}
+	return s.Fail(fmt.Sprintf("No record logged matching: %s\nafter the records matching the %d previous ones of the sequence\n%s", matchers[next], next, describeLogs(records)))
+}
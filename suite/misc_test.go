@@ -1,6 +1,8 @@
 package suite
 
 import (
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +30,42 @@ func TestCreateAndSetField(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "value of type string is not assignable to field Field2 of type int")
 }
+
+func TestParseExampleFile(t *testing.T) {
+	exampleFiles.Lock()
+	defer exampleFiles.Unlock()
+
+	// With -trimpath, the file is relative to the module.
+	f, err := parseExampleFile("github.com/varunbpatil/testify/suite/misc_test.go")
+	assert.NoError(t, err)
+	assert.NotNil(t, f)
+
+	// The test binary may have been moved away from the sources.
+	_, err = parseExampleFile(filepath.Join(t.TempDir(), "example_test.go"))
+	assert.Error(t, err)
+}
+
+func TestNewViewFields(t *testing.T) {
+	// Each field of Suite is either set by newView, through setTB like for any
+	// test, or left to its zero value on purpose. A new field fails this test
+	// until newView is updated for it.
+	fields := []string{
+		// Shared with the test.
+		"testingT", "testingB", "testingF", "g", "parent", "phase", "info",
+		"snapshots", "snapshotCount", "output", "ignoredGoroutines", "parallel",
+		"tempDir", "exclusion", "readOnly", "logs",
+		// Set by setTB.
+		"Assertions", "require", "tb", "ctx",
+		// Set by the caller.
+		"suite",
+		// Specific to the view.
+		"phaseMu", "skip", "children", "teardown",
+	}
+
+	typ := reflect.TypeOf(Suite[TestStruct, TestStruct]{})
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		names = append(names, typ.Field(i).Name)
+	}
+	assert.ElementsMatch(t, fields, names)
+}
@@ -0,0 +1,122 @@
+package suite
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// capturedOutput is the output captured for a test method, including the
+// output of its subtests. Each line is prefixed with the name of the test or
+// subtest that wrote it.
+type capturedOutput struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (c *capturedOutput) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.String()
+}
+
+// testOutput is the writer returned by [Suite.Stdout] when the output is
+// captured. Lines are added to the captured output of the test method as a
+// whole, so that the output of parallel subtests doesn't interleave within a
+// line.
+type testOutput struct {
+	name     string
+	captured *capturedOutput
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (o *testOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.partial = append(o.partial, p...)
+	if i := bytes.LastIndexByte(o.partial, '\n'); i >= 0 {
+		o.add(o.partial[:i+1])
+		o.partial = append(o.partial[:0], o.partial[i+1:]...)
+	}
+	return len(p), nil
+}
+
+// flush adds the last line written to the captured output, even if it is
+// incomplete.
+func (o *testOutput) flush() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.partial) > 0 {
+		o.add(append(o.partial, '\n'))
+		o.partial = nil
+	}
+}
+
+// add adds complete lines to the captured output, prefixed with the name of
+// the test.
+func (o *testOutput) add(lines []byte) {
+	o.captured.mu.Lock()
+	defer o.captured.mu.Unlock()
+
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			o.captured.buf.WriteString(o.name + ": ")
+			o.captured.buf.Write(line)
+		}
+	}
+}
+
+// Stdout returns the writer for the output of the test. Unless the suite
+// captures the output of its tests (see [CaptureOutput]), this is os.Stdout.
+func (s *Suite[T, G]) Stdout() io.Writer {
+	if s.output == nil {
+		return os.Stdout
+	}
+	return s.output
+}
+
+// Stderr is like [Suite.Stdout], for os.Stderr. When the output of the tests
+// is captured, both are captured together.
+func (s *Suite[T, G]) Stderr() io.Writer {
+	if s.output == nil {
+		return os.Stderr
+	}
+	return s.output
+}
+
+// captureOutput sets up the capture of the output of the test, if the suite
+// captures the output of its tests. For a test method, parent is nil and the
+// captured output, including the output of its subtests, is logged by the test
+// once it and all of its cleanup functions are done, so that, like the other
+// logs of the test, it is only shown if the test failed or if the tests are
+// run with -v. It should be called before registering the cleanup functions
+// that may write output, so that their output is captured.
+func (s *Suite[T, G]) captureOutput(parent *testOutput) {
+	if parent == nil {
+		captureOutput, ok := any(s.suite).(CaptureOutput)
+		if !ok || !captureOutput.CaptureOutput() {
+			return
+		}
+	}
+
+	s.output = &testOutput{name: s.Name()}
+	if parent != nil {
+		s.output.captured = parent.captured
+		s.Cleanup(s.output.flush)
+		return
+	}
+	s.output.captured = &capturedOutput{}
+	s.Cleanup(func() {
+		s.tb.Helper()
+		s.output.flush()
+		if output := s.output.captured.String(); output != "" {
+			s.tb.Log("captured output:\n" + strings.TrimSuffix(output, "\n"))
+		}
+	})
+}
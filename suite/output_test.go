@@ -0,0 +1,77 @@
+package suite_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// outputSuite is intended to test that the output of the tests is captured
+// and only printed for the tests that failed.
+type outputSuite struct {
+	*suite.Suite[outputSuite, outputSuiteGlobalData]
+}
+
+type outputSuiteGlobalData struct{}
+
+var outputStats *suite.SuiteInformation
+
+func (s *outputSuite) CaptureOutput() bool {
+	return true
+}
+
+func (s *outputSuite) HandleStats(suiteName string, stats *suite.SuiteInformation) {
+	outputStats = stats
+}
+
+func (s *outputSuite) TearDownTest() {
+	fmt.Fprintln(s.Stdout(), "teardown")
+}
+
+func (s *outputSuite) TestPass() {
+	s.Parallel()
+	fmt.Fprintln(s.Stdout(), "PASSOUTPUT")
+}
+
+func (s *outputSuite) TestFail() {
+	s.Parallel()
+	fmt.Fprint(s.Stdout(), "first ")
+	fmt.Fprintln(s.Stderr(), "line")
+	s.Run("sub", func(s *outputSuite) {
+		fmt.Fprint(s.Stdout(), "no newline")
+	})
+	s.Fail("failed")
+}
+
+func TestSuiteCaptureOutput(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/outputSuite",
+		F: func(t *testing.T) {
+			suite.Run[outputSuite, outputSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The output is logged by the test, one line after the other.
+	name := t.Name() + "/outputSuite/TestFail"
+	failOutput := name + ": first line\n" + name + "/sub: no newline\n" + name + ": teardown\n"
+	assert.Regexp(t, `\.go:\d+: captured output:\n\s+`+name+`: first line\n\s+`+name+`/sub: no newline\n\s+`+name+`: teardown\n`, output)
+	if testing.Verbose() {
+		assert.Contains(t, output, "PASSOUTPUT")
+	} else {
+		assert.NotContains(t, output, "PASSOUTPUT")
+	}
+
+	require.NotNil(t, outputStats)
+	assert.Equal(t, failOutput, outputStats.TestStats["TestFail"].Output)
+	passName := t.Name() + "/outputSuite/TestPass"
+	assert.Equal(t, passName+": PASSOUTPUT\n"+passName+": teardown\n", outputStats.TestStats["TestPass"].Output)
+}
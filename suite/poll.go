@@ -0,0 +1,155 @@
+package suite
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// CollectT collects the failures of the assertions made by the condition
+// passed to [Suite.EventuallyWithTContext]. It can be passed to the functions
+// of the assert and require packages.
+type CollectT struct {
+	errors []string
+	failed bool
+}
+
+// Errorf records a failure.
+func (c *CollectT) Errorf(format string, args ...any) {
+	c.errors = append(c.errors, fmt.Sprintf(format, args...))
+	c.failed = true
+}
+
+// FailNow records a failure and stops the execution of the condition.
+func (c *CollectT) FailNow() {
+	c.failed = true
+	runtime.Goexit()
+}
+
+// Helper does nothing. It is only there so that the assert and require
+// packages don't report the location of their own functions.
+func (c *CollectT) Helper() {}
+
+// EventuallyContext asserts that condition returns true within waitFor,
+// calling it every tick starting right away. Unlike
+// [assert.Assertions.Eventually], it gives up as soon as the context of the
+// test is done, e.g. shortly before the test deadline set by the -timeout
+// flag is reached.
+func (s *Suite[T, G]) EventuallyContext(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...any) bool {
+	s.tb.Helper()
+
+	ok, err := s.poll(waitFor, tick, true, condition)
+	switch {
+	case ok:
+		return true
+	case err != nil:
+		return assert.Fail(s.tb, fmt.Sprintf("Condition never satisfied before the test context was done: %v", err), msgAndArgs...)
+	}
+	return assert.Fail(s.tb, "Condition never satisfied", msgAndArgs...)
+}
+
+// EventuallyWithTContext asserts that the assertions made by condition on the
+// given [CollectT] all pass within waitFor, calling it every tick starting
+// right away. Like [Suite.EventuallyContext], it gives up as soon as the
+// context of the test is done. On failure, the failures of the last call to
+// condition are reported.
+func (s *Suite[T, G]) EventuallyWithTContext(condition func(c *CollectT), waitFor, tick time.Duration, msgAndArgs ...any) bool {
+	s.tb.Helper()
+
+	var (
+		mu   sync.Mutex
+		last *CollectT
+	)
+	ok, err := s.poll(waitFor, tick, true, func() bool {
+		c := &CollectT{}
+		// The failures are recorded even if condition calls c.FailNow.
+		defer func() {
+			mu.Lock()
+			defer mu.Unlock()
+			last = c
+		}()
+		condition(c)
+		return !c.failed
+	})
+	if ok {
+		return true
+	}
+
+	message := "Condition never satisfied"
+	if err != nil {
+		message = fmt.Sprintf("Condition never satisfied before the test context was done: %v", err)
+	}
+	mu.Lock()
+	if last != nil && len(last.errors) > 0 {
+		message += "\nlast failure:\n" + strings.Join(last.errors, "\n")
+	}
+	mu.Unlock()
+	return assert.Fail(s.tb, message, msgAndArgs...)
+}
+
+// ConsistentlyContext asserts that condition keeps returning true for waitFor,
+// calling it every tick starting right away. It fails as soon as condition
+// returns false or stops, e.g. by calling runtime.Goexit, or if the context of
+// the test is done before waitFor.
+func (s *Suite[T, G]) ConsistentlyContext(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...any) bool {
+	s.tb.Helper()
+
+	start := time.Now()
+	failed, err := s.poll(waitFor, tick, false, condition)
+	switch {
+	case failed:
+		return assert.Fail(s.tb, fmt.Sprintf("Condition not satisfied after %s", time.Since(start).Round(time.Millisecond)), msgAndArgs...)
+	case err != nil:
+		return assert.Fail(s.tb, fmt.Sprintf("Condition not checked for %s, the test context was done: %v", waitFor, err), msgAndArgs...)
+	}
+	return true
+}
+
+// poll calls condition every tick, starting right away, until it returns
+// until, waitFor has elapsed or the context of the test is done. It returns
+// whether condition returned until and, if the context of the test is done
+// first, its error. Each call to condition runs in its own goroutine, so that
+// a blocked condition doesn't prevent poll from returning.
+func (s *Suite[T, G]) poll(waitFor, tick time.Duration, until bool, condition func() bool) (bool, error) {
+	ctx := s.Context()
+
+	timer := time.NewTimer(waitFor)
+	defer timer.Stop()
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	var tickC <-chan time.Time
+	check := func() chan bool {
+		result := make(chan bool, 1)
+		go func() {
+			// The result is sent even if condition calls runtime.Goexit, in
+			// which case it counts as not satisfied.
+			var ok bool
+			defer func() { result <- ok }()
+			ok = condition()
+		}()
+		return result
+	}
+	result := check()
+	for {
+		select {
+		case <-timer.C:
+			return false, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-tickC:
+			tickC = nil
+			result = check()
+		case ok := <-result:
+			if ok == until {
+				return true, nil
+			}
+			result = nil
+			tickC = ticker.C
+		}
+	}
+}
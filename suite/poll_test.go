@@ -0,0 +1,166 @@
+package suite_test
+
+import (
+	"flag"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// pollSuite is intended to test the polling assertions of the suite.
+type pollSuite struct {
+	*suite.Suite[pollSuite, pollSuiteGlobalData]
+}
+
+type pollSuiteGlobalData struct{}
+
+func (s *pollSuite) TestEventuallyContext() {
+	var calls int32
+	s.True(s.EventuallyContext(func() bool {
+		return atomic.AddInt32(&calls, 1) == 3
+	}, time.Second, time.Millisecond))
+}
+
+func (s *pollSuite) TestEventuallyWithTContext() {
+	var calls int32
+	s.True(s.EventuallyWithTContext(func(c *suite.CollectT) {
+		assert.Equal(c, int32(3), atomic.AddInt32(&calls, 1))
+	}, time.Second, time.Millisecond))
+}
+
+func (s *pollSuite) TestConsistentlyContext() {
+	s.True(s.ConsistentlyContext(func() bool { return true }, 20*time.Millisecond, time.Millisecond))
+}
+
+// The polling assertions of the assert package are still promoted.
+func (s *pollSuite) TestAssertPolling() {
+	var calls int32
+	s.True(s.Eventually(func() bool {
+		return atomic.AddInt32(&calls, 1) == 3
+	}, time.Second, time.Millisecond))
+	s.True(s.EventuallyWithT(func(c *assert.CollectT) {
+		assert.Equal(c, int32(5), atomic.AddInt32(&calls, 1))
+	}, time.Second, time.Millisecond))
+	s.True(s.Never(func() bool { return false }, 20*time.Millisecond, time.Millisecond))
+}
+
+func TestSuitePoll(t *testing.T) {
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/pollSuite",
+		F: func(t *testing.T) {
+			suite.Run[pollSuite, pollSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+}
+
+// pollFailureSuite is intended to test the failures reported by the polling
+// assertions of the suite.
+type pollFailureSuite struct {
+	*suite.Suite[pollFailureSuite, pollFailureSuiteGlobalData]
+}
+
+type pollFailureSuiteGlobalData struct{}
+
+func (s *pollFailureSuite) TestEventuallyWithTContext() {
+	var calls int32
+	s.EventuallyWithTContext(func(c *suite.CollectT) {
+		assert.Less(c, atomic.AddInt32(&calls, 1), int32(0))
+		require.Fail(c, "not ready yet")
+		assert.Fail(c, "never reached")
+	}, 20*time.Millisecond, 5*time.Millisecond)
+}
+
+func (s *pollFailureSuite) TestConsistentlyContext() {
+	var calls int32
+	s.ConsistentlyContext(func() bool {
+		return atomic.AddInt32(&calls, 1) < 3
+	}, time.Second, time.Millisecond)
+}
+
+func (s *pollFailureSuite) TestConsistentlyContextGoexit() {
+	var calls int32
+	s.ConsistentlyContext(func() bool {
+		if atomic.AddInt32(&calls, 1) == 3 {
+			runtime.Goexit()
+		}
+		return true
+	}, time.Second, time.Millisecond)
+}
+
+func (s *pollFailureSuite) TestEventuallyContextGoexit() {
+	s.EventuallyContext(func() bool {
+		runtime.Goexit()
+		return true
+	}, 20*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestSuitePollFailure(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/pollFailureSuite",
+		F: func(t *testing.T) {
+			suite.Run[pollFailureSuite, pollFailureSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuitePollFailure/pollFailureSuite/TestEventuallyWithTContext")
+	assert.Contains(t, output, "Condition never satisfied")
+	assert.Contains(t, output, "last failure:")
+	assert.Contains(t, output, "not ready yet")
+	assert.NotContains(t, output, "never reached")
+
+	assert.Contains(t, output, "--- FAIL: TestSuitePollFailure/pollFailureSuite/TestConsistentlyContext ")
+	assert.Contains(t, output, "Condition not satisfied after")
+
+	// A condition that stops counts as not satisfied.
+	assert.Contains(t, output, "--- FAIL: TestSuitePollFailure/pollFailureSuite/TestConsistentlyContextGoexit")
+	assert.Contains(t, output, "--- FAIL: TestSuitePollFailure/pollFailureSuite/TestEventuallyContextGoexit")
+}
+
+// pollDeadlineSuite is intended to test that the polling assertions of the
+// suite give up once the test deadline is reached.
+type pollDeadlineSuite struct {
+	*suite.Suite[pollDeadlineSuite, pollDeadlineSuiteGlobalData]
+}
+
+type pollDeadlineSuiteGlobalData struct{}
+
+func (s *pollDeadlineSuite) TestEventuallyContext() {
+	start := time.Now()
+	s.EventuallyContext(func() bool { return false }, time.Hour, time.Millisecond)
+	// The context of the test is done before the test deadline.
+	s.Less(time.Since(start), time.Second)
+}
+
+func TestSuitePollDeadline(t *testing.T) {
+	// The tests run by testing.RunTests get a deadline from the -timeout flag.
+	timeout := flag.Lookup("test.timeout").Value.String()
+	require.NoError(t, flag.Set("test.timeout", "1s"))
+	defer func() { require.NoError(t, flag.Set("test.timeout", timeout)) }()
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/pollDeadlineSuite",
+		F: func(t *testing.T) {
+			suite.Run[pollDeadlineSuite, pollDeadlineSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuitePollDeadline/pollDeadlineSuite/TestEventuallyContext")
+	assert.Contains(t, output, "Condition never satisfied before the test context was done: context deadline exceeded")
+}
@@ -0,0 +1,286 @@
+package suite
+
+import (
+	"os"
+	"sync"
+)
+
+// What a test holds on its lock, see [exclusion].
+const (
+	heldNone = iota
+	heldRead
+	heldWrite
+)
+
+// exclusion makes the tests that change the state of the process, like the
+// environment or the working directory, run exclusively against the other
+// tests of the suite. A parallel test holds a lock for reading, and a test
+// changing the state of the process holds it for writing, until it and all of
+// its cleanup functions are done. A test with parallel subtests releases it
+// while they run, since they take it themselves, and takes it again for its
+// cleanup functions once they are done. The lock is the
+// one of the suite, or the one of the closest exclusive parent of the test, so
+// that the parallel subtests of an exclusive test are run exclusively against
+// each other as well.
+type exclusion struct {
+	parent   *exclusion
+	children sync.RWMutex // taken by the children if the test is exclusive
+
+	mu        sync.Mutex
+	parallel  bool
+	lock      *sync.RWMutex // the lock held by the test
+	held      int
+	exclusive bool
+	subtests  bool // whether the test has parallel subtests
+}
+
+func newExclusion(parent *exclusion) *exclusion {
+	return &exclusion{parent: parent}
+}
+
+// contextLock returns the lock of the closest exclusive parent, or of the
+// suite.
+func (e *exclusion) contextLock() *sync.RWMutex {
+	for p := e.parent; ; p = p.parent {
+		if p.parent == nil || p.isExclusive() {
+			return &p.children
+		}
+	}
+}
+
+// pause records that the parent of the test has parallel subtests, before the
+// test is paused by t.Parallel until the body of its parent is done.
+func (e *exclusion) pause() {
+	if e == nil || e.parent == nil {
+		return
+	}
+	e.parent.mu.Lock()
+	defer e.parent.mu.Unlock()
+	e.parent.subtests = true
+}
+
+// resume takes the lock for reading once the test has been resumed by
+// t.Parallel.
+func (e *exclusion) resume() {
+	if e == nil || e.parent == nil {
+		return
+	}
+	lock := e.contextLock()
+	lock.RLock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.parallel = true
+	e.lock = lock
+	e.held = heldRead
+}
+
+// bodyDone releases the lock held for reading once the body of the test is
+// done if the test has parallel subtests, since they take it themselves, and
+// registers with cleanup the function taking it again once they are done.
+// Holding it while they run could deadlock, when they take it while a test
+// waits to take it for writing.
+func (e *exclusion) bodyDone(cleanup func(func())) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.held == heldRead && e.subtests {
+		e.lock.RUnlock()
+		e.held = heldNone
+		cleanup(e.reacquire)
+	}
+}
+
+// reacquire takes the lock released by bodyDone again for the cleanup
+// functions of the test, unless the test has taken it for writing since.
+func (e *exclusion) reacquire() {
+	e.mu.Lock()
+	held := e.held
+	e.mu.Unlock()
+	if held != heldNone {
+		return
+	}
+	lock := e.contextLock()
+	lock.RLock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lock = lock
+	e.held = heldRead
+}
+
+// makeExclusive waits for the other tests of the suite that may run in
+// parallel with the test to be done, and prevents the others from starting
+// until it is done. The lock held for reading by a parallel test is released
+// before being taken for writing, so that two parallel tests can become
+// exclusive without waiting for each other. A test that isn't parallel runs
+// within the body of its parent, which is made exclusive instead.
+func (e *exclusion) makeExclusive() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exclusive {
+		return
+	}
+
+	switch {
+	case e.held == heldRead:
+		e.lock.RUnlock()
+		e.lock.Lock()
+		e.held = heldWrite
+	case e.parallel:
+		// The body of the test is done, e.g. when called from a cleanup
+		// function.
+		e.lock = e.contextLock()
+		e.lock.Lock()
+		e.held = heldWrite
+	default:
+		e.parent.makeExclusive()
+	}
+	e.exclusive = true
+}
+
+func (e *exclusion) isExclusive() bool {
+	if e == nil {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exclusive
+}
+
+// release releases the lock once the test and all of its cleanup functions
+// are done.
+func (e *exclusion) release() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch e.held {
+	case heldRead:
+		e.lock.RUnlock()
+	case heldWrite:
+		e.lock.Unlock()
+	}
+	e.held = heldNone
+}
+
+// Parallel signals that the test is to be run in parallel with (and only with)
+// other parallel tests, see [testing.T.Parallel].
+//
+// Calling Parallel once the test has changed the state of the process with
+// [Suite.Exclusive] or one of the helpers calling it, like [Suite.Setenv], has
+// no effect: the test keeps running sequentially.
+func (s *Suite[T, G]) Parallel() {
+	if s.T() == nil {
+		s.tb.Helper()
+		s.tb.Fatalf("Parallel requires a *testing.T")
+		return
+	}
+	if s.exclusion.isExclusive() {
+		return
+	}
+	s.parallel = true
+	if s.readOnly != nil {
+		s.readOnly.pause(s.G(), s.info.Path[0])
+		defer s.readOnly.start(s.info.Path[0])
+	}
+	s.exclusion.pause()
+	s.T().Parallel()
+	s.exclusion.resume()
+}
+
+// Exclusive declares that the test changes the state of the whole process,
+// e.g. a global variable, so that it doesn't run in parallel with the other
+// tests of the suite, until it and all of its cleanup functions are done. If
+// the test is parallel, Exclusive waits for the other parallel tests that are
+// running to be done.
+//
+// Helpers changing the state of the process, like [Suite.Setenv] and
+// [Suite.Chdir], call it so that they can be used in parallel tests.
+func (s *Suite[T, G]) Exclusive() {
+	s.exclusion.makeExclusive()
+}
+
+// Setenv sets the environment variable key to value, and restores its
+// previous value once the test and all of its cleanup functions registered
+// after calling Setenv are done. Unlike [testing.T.Setenv], it can be called
+// from parallel tests, which then run exclusively, see [Suite.Exclusive].
+func (s *Suite[T, G]) Setenv(key, value string) {
+	s.tb.Helper()
+	s.Exclusive()
+
+	s.restoreEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		s.tb.Fatalf("Setenv: %v", err)
+	}
+}
+
+// Unsetenv unsets the environment variable key, like [Suite.Setenv].
+func (s *Suite[T, G]) Unsetenv(key string) {
+	s.tb.Helper()
+	s.Exclusive()
+
+	s.restoreEnv(key)
+	if err := os.Unsetenv(key); err != nil {
+		s.tb.Fatalf("Unsetenv: %v", err)
+	}
+}
+
+// restoreEnv restores the current value of the environment variable key once
+// the test is done.
+func (s *Suite[T, G]) restoreEnv(key string) {
+	previous, ok := os.LookupEnv(key)
+	s.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Chdir changes the working directory to dir, and changes it back once the
+// test and all of its cleanup functions registered after calling Chdir are
+// done. Like [Suite.Setenv], it can be called from parallel tests, which then
+// run exclusively.
+func (s *Suite[T, G]) Chdir(dir string) {
+	s.tb.Helper()
+	s.Exclusive()
+
+	previous, err := os.Getwd()
+	if err != nil {
+		s.tb.Fatalf("Chdir: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		s.tb.Fatalf("Chdir: %v", err)
+	}
+	s.Cleanup(func() {
+		if err := os.Chdir(previous); err != nil {
+			s.tb.Errorf("Chdir: restoring the working directory: %v", err)
+		}
+	})
+}
+
+// SetGlobal sets the global variable pointed to by ptr to value, and restores
+// its previous value once the test and all of its cleanup functions
+// registered after calling SetGlobal are done. The test runs exclusively, see
+// [Suite.Exclusive].
+//
+//	suite.SetGlobal(s, &http.DefaultClient, s.G().Client)
+func SetGlobal[V any](s interface {
+	Exclusive()
+	Cleanup(func())
+}, ptr *V, value V,
+) {
+	s.Exclusive()
+
+	previous := *ptr
+	*ptr = value
+	s.Cleanup(func() { *ptr = previous })
+}
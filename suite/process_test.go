@@ -0,0 +1,123 @@
+package suite_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// processSuite is intended to test that the parallel tests changing the state
+// of the process run exclusively, and that the state is restored.
+type processSuite struct {
+	*suite.Suite[processSuite, processSuiteGlobalData]
+	reader bool
+}
+
+type processSuiteGlobalData struct{}
+
+var processGlobal = "initial"
+
+// checkState checks that the state of the process isn't changed by another
+// test for a while.
+func (s *processSuite) checkState(env, wd, global string) {
+	for i := 0; i < 10; i++ {
+		s.Equal(env, os.Getenv("TESTIFY_PROCESS"))
+		cwd, err := os.Getwd()
+		s.NoError(err)
+		s.Equal(wd, cwd)
+		s.Equal(global, processGlobal)
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *processSuite) TestReader() {
+	s.Parallel()
+	s.reader = true
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.checkState("", wd, "initial")
+}
+
+// TearDownTest checks that the state of the process isn't changed by another
+// test until the cleanup functions of a reader are done.
+func (s *processSuite) TearDownTest() {
+	if !s.reader {
+		return
+	}
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.checkState("", wd, "initial")
+}
+
+func (s *processSuite) TestSetenv() {
+	s.Parallel()
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.Setenv("TESTIFY_PROCESS", "setenv")
+	s.checkState("setenv", wd, "initial")
+}
+
+func (s *processSuite) TestSetGlobal() {
+	s.Parallel()
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	suite.SetGlobal(s, &processGlobal, "set")
+	s.checkState("", wd, "set")
+}
+
+func (s *processSuite) TestSubtests() {
+	s.Parallel()
+	for _, name := range []string{"a", "b"} {
+		name := name
+		s.Run(name, func(s *processSuite) {
+			s.Parallel()
+			dir, err := filepath.EvalSymlinks(s.TempDir())
+			s.Require().NoError(err)
+			s.Chdir(dir)
+			s.Unsetenv("TESTIFY_PROCESS")
+			s.checkState("", dir, "initial")
+		})
+	}
+}
+
+func (s *processSuite) TestSequential() {
+	s.Setenv("TESTIFY_PROCESS", "sequential")
+	// The test has already changed the environment, so it keeps running
+	// sequentially.
+	s.Parallel()
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.checkState("sequential", wd, "initial")
+}
+
+func TestSuiteProcessState(t *testing.T) {
+	// The tests have to be able to run at the same time.
+	parallel := flag.Lookup("test.parallel").Value.String()
+	require.NoError(t, flag.Set("test.parallel", "4"))
+	defer func() { require.NoError(t, flag.Set("test.parallel", parallel)) }()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/processSuite",
+		F: func(t *testing.T) {
+			suite.Run[processSuite, processSuiteGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+
+	_, set := os.LookupEnv("TESTIFY_PROCESS")
+	assert.False(t, set)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, cwd)
+	assert.Equal(t, "initial", processGlobal)
+}
@@ -0,0 +1,278 @@
+package suite
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var concurrencySafeType = reflect.TypeOf((*concurrencySafe)(nil)).Elem()
+
+// globalSnapshot is a snapshot of the global data of a suite, used to detect
+// the modifications made to it by the tests. A deep snapshot also covers the
+// values that the global data refers to.
+//
+// The global data is only checked while none of the tests of the suite is
+// running, so that the check doesn't race with them: when a test is paused
+// to run in parallel with the other tests, when a test that isn't parallel is
+// done, and when the last of the parallel tests running at the same time is
+// done. A modification is attributed to the tests that may have been running
+// since the global data was last checked. If there is only one, like for the
+// tests that aren't parallel, that test fails. Otherwise the modification
+// can't be traced back to a single test, and the suite fails instead, naming
+// all of them. The check is best-effort: the goroutines started by the tests
+// and still running when they are done aren't accounted for.
+type globalSnapshot struct {
+	mu      sync.Mutex
+	deep    bool
+	values  map[string]string
+	suite   testing.TB          // where the modifications made by parallel tests are reported
+	running map[string]bool     // tests whose code may be running
+	ran     map[string]bool     // tests whose code may have run since the last check
+	found   map[string][]string // modifications attributed to a single test
+}
+
+func newGlobalSnapshot(suite testing.TB, g any, deep bool) *globalSnapshot {
+	return &globalSnapshot{
+		deep:    deep,
+		values:  fingerprintGlobal(g, deep),
+		suite:   suite,
+		running: make(map[string]bool),
+		ran:     make(map[string]bool),
+		found:   make(map[string][]string),
+	}
+}
+
+// start marks the test as running, until it is paused or done.
+func (s *globalSnapshot) start(test string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running[test] = true
+	s.ran[test] = true
+}
+
+// pause marks the test as not running, before it is paused to run in
+// parallel with the other tests, and checks the global data if no other test
+// is running. Since the parallel tests only resume once the tests that aren't
+// parallel are done, the test is the only one running at this point.
+func (s *globalSnapshot) pause(g any, test string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop(g, test)
+}
+
+// done marks the test as not running once it is done, checks the global data
+// if no other test is running, and returns the modifications attributed to the
+// test alone.
+func (s *globalSnapshot) done(g any, test string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop(g, test)
+	found := s.found[test]
+	delete(s.found, test)
+	return found
+}
+
+func (s *globalSnapshot) stop(g any, test string) {
+	delete(s.running, test)
+	if len(s.running) == 0 {
+		s.check(g)
+	}
+}
+
+// check compares the global data against the snapshot and attributes the
+// modifications to the tests that may have been running since the last check.
+// The snapshot is then updated so that each modification is only reported
+// once.
+func (s *globalSnapshot) check(g any) {
+	values := fingerprintGlobal(g, s.deep)
+
+	var suspects []string
+	for test := range s.ran {
+		suspects = append(suspects, test)
+	}
+	sort.Strings(suspects)
+	s.ran = make(map[string]bool)
+
+	var modified []string
+	for path, value := range values {
+		if old, ok := s.values[path]; !ok || old != value {
+			if !ok {
+				old = "<none>"
+			}
+			modified = append(modified, fmt.Sprintf("%s: %s -> %s", path, old, value))
+		}
+	}
+	for path, old := range s.values {
+		if _, ok := values[path]; !ok {
+			modified = append(modified, fmt.Sprintf("%s: %s -> <none>", path, old))
+		}
+	}
+	s.values = values
+	if len(modified) == 0 {
+		return
+	}
+	sort.Strings(modified)
+
+	if len(suspects) == 1 {
+		s.found[suspects[0]] = append(s.found[suspects[0]], modified...)
+		return
+	}
+	s.suite.Errorf("global data is read-only but was modified by one of the tests running in parallel (%s):\n  %s",
+		strings.Join(suspects, ", "), strings.Join(modified, "\n  "))
+}
+
+// failIfModified fails the current test if the global data was modified while
+// it was the only test running.
+func (s *Suite[T, G]) failIfModified(snapshot *globalSnapshot) {
+	s.tb.Helper()
+	if modified := snapshot.done(s.G(), s.info.Path[0]); len(modified) > 0 {
+		s.tb.Errorf("global data is read-only but was modified while the test was running:\n  %s",
+			strings.Join(modified, "\n  "))
+	}
+}
+
+// fingerprintGlobal describes every value stored in the global data g (which
+// is a pointer), keyed by the path of the field holding it.
+func fingerprintGlobal(g any, deep bool) map[string]string {
+	v := reflect.ValueOf(g).Elem()
+	f := &fingerprinter{
+		deep:    deep,
+		values:  make(map[string]string),
+		visited: make(map[visit]bool),
+	}
+	f.fingerprint(v.Type().Name(), v, deep)
+	return f.values
+}
+
+// fingerprinter describes every value stored in a value, keyed by the path of
+// the field holding it, e.g. "GlobalData.Config.Timeout".
+//
+// Unless deep is set, pointers, maps and slices are described by their address
+// (and length) only, so the values they refer to aren't compared. Channels and
+// functions are always described by their address only.
+//
+// Values of types that are safe to be modified concurrently, like [Shared],
+// [Counter] and the types of the sync package, and struct fields tagged with
+// `testify:"mutable"` are skipped. Struct fields tagged with `testify:"shallow"`
+// are never followed, even if deep is set.
+type fingerprinter struct {
+	deep    bool
+	values  map[string]string
+	visited map[visit]bool // pointers already followed, to avoid cycles
+}
+
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (f *fingerprinter) fingerprint(path string, v reflect.Value, deep bool) {
+	if v.CanAddr() && v.Addr().Type().Implements(concurrencySafeType) {
+		return
+	}
+	if pkg := v.Type().PkgPath(); pkg == "sync" || pkg == "sync/atomic" {
+		return
+	}
+
+	values := f.values
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			switch field.Tag.Get("testify") {
+			case "mutable":
+				continue
+			case "shallow":
+				f.fingerprint(path+"."+field.Name, v.Field(i), false)
+			default:
+				f.fingerprint(path+"."+field.Name, v.Field(i), deep)
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			f.fingerprint(fmt.Sprintf("%s[%d]", path, i), v.Index(i), deep)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			values[path] = "nil"
+			return
+		}
+		values[path] = v.Elem().Type().String()
+		f.fingerprint(fmt.Sprintf("%s.(%s)", path, v.Elem().Type()), v.Elem(), deep)
+	case reflect.Ptr:
+		if !deep {
+			values[path] = fmt.Sprintf("%#x", v.Pointer())
+			return
+		}
+		if v.IsNil() {
+			values[path] = "nil"
+			return
+		}
+		// Like field selectors, pointers are followed without changing the path.
+		if !f.visit(v) {
+			values[path] = "&" + v.Elem().Type().String()
+			f.fingerprint(path, v.Elem(), deep)
+		}
+	case reflect.Map:
+		if !deep {
+			values[path] = fmt.Sprintf("%#x", v.Pointer())
+			return
+		}
+		if v.IsNil() {
+			values[path] = "nil"
+			return
+		}
+		values[path] = fmt.Sprintf("%s (len %d)", v.Type(), v.Len())
+		if f.visit(v) {
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			f.fingerprint(fmt.Sprintf("%s[%v]", path, key), v.MapIndex(key), deep)
+		}
+	case reflect.Slice:
+		if !deep {
+			values[path] = fmt.Sprintf("%#x (len %d)", v.Pointer(), v.Len())
+			return
+		}
+		if v.IsNil() {
+			values[path] = "nil"
+			return
+		}
+		values[path] = fmt.Sprintf("%s (len %d)", v.Type(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			f.fingerprint(fmt.Sprintf("%s[%d]", path, i), v.Index(i), deep)
+		}
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		values[path] = fmt.Sprintf("%#x", v.Pointer())
+	case reflect.String:
+		values[path] = strconv.Quote(v.String())
+	case reflect.Bool:
+		values[path] = strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values[path] = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		values[path] = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		values[path] = strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Complex64, reflect.Complex128:
+		values[path] = fmt.Sprint(v.Complex())
+	}
+}
+
+// visit marks the value v (a pointer or a map) as visited and reports whether
+// it was already visited.
+func (f *fingerprinter) visit(v reflect.Value) bool {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if f.visited[key] {
+		return true
+	}
+	f.visited[key] = true
+	return false
+}
@@ -0,0 +1,204 @@
+package suite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resourceTimeout is how long the resources leaked by a test are given to be
+// released once it is done, e.g. for connections closed asynchronously.
+var resourceTimeout = time.Second
+
+// processResources is a snapshot of the state of the process that tests are
+// checked not to leak.
+type processResources struct {
+	fds     map[int]string // targets of the open file descriptors, nil if unknown
+	cwd     string
+	env     map[string]string
+	tempDir string
+	temp    map[string]bool // entries of tempDir, nil if it isn't the one of the suite
+}
+
+// ignoredFDs are the targets of the file descriptors opened by the runtime the
+// first time they are needed, e.g. for the network poller.
+var ignoredFDs = []string{"anon_inode:[eventpoll]", "anon_inode:[eventfd]", "anon_inode:[timerfd]"}
+
+// snapshotResources snapshots the state of the process. The entries of the
+// temporary directory are only listed if it is suiteTempDir, since the other
+// processes may write to the temporary directory of the system too.
+func snapshotResources(suiteTempDir string) *processResources {
+	r := &processResources{
+		env:     map[string]string{},
+		tempDir: os.TempDir(),
+	}
+
+	// The file descriptors can only be listed where /proc is available.
+	if entries, err := os.ReadDir("/proc/self/fd"); err == nil {
+		r.fds = map[int]string{}
+		procFD := fmt.Sprintf("/proc/%d/fd", os.Getpid())
+		for _, entry := range entries {
+			fd, err := strconv.Atoi(entry.Name())
+			if err != nil {
+				continue
+			}
+			// The file descriptor may have been closed in between, and the one
+			// used to list the file descriptors is closed once they are listed.
+			target, err := os.Readlink(filepath.Join("/proc/self/fd", entry.Name()))
+			if err != nil || target == procFD {
+				continue
+			}
+			r.fds[fd] = target
+		}
+	}
+
+	// The working directory may have been removed.
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = fmt.Sprintf("unknown (%v)", err)
+	}
+	r.cwd = cwd
+
+	for _, kv := range os.Environ() {
+		if i := strings.Index(kv, "="); i > 0 {
+			r.env[kv[:i]] = kv[i+1:]
+		}
+	}
+
+	if suiteTempDir != "" && r.tempDir == suiteTempDir {
+		r.temp = map[string]bool{}
+		if entries, err := os.ReadDir(r.tempDir); err == nil {
+			for _, entry := range entries {
+				r.temp[entry.Name()] = true
+			}
+		}
+	}
+	return r
+}
+
+// leaks lists what changed since before, as described to the test.
+func (r *processResources) leaks(before *processResources) []string {
+	var leaks []string
+
+	if r.fds != nil && before.fds != nil {
+		var fds []int
+		for fd, target := range r.fds {
+			if before.fds[fd] != target && !ignoredFD(target) {
+				fds = append(fds, fd)
+			}
+		}
+		sort.Ints(fds)
+		for _, fd := range fds {
+			leaks = append(leaks, fmt.Sprintf("file descriptor %d left open: %s", fd, r.fds[fd]))
+		}
+	}
+
+	if r.cwd != before.cwd {
+		leaks = append(leaks, fmt.Sprintf("working directory changed from %s to %s", before.cwd, r.cwd))
+	}
+
+	var envLeaks []string
+	for key, value := range r.env {
+		if previous, ok := before.env[key]; !ok {
+			envLeaks = append(envLeaks, fmt.Sprintf("environment variable %s set to %q, was unset", key, value))
+		} else if previous != value {
+			envLeaks = append(envLeaks, fmt.Sprintf("environment variable %s set to %q, was %q", key, value, previous))
+		}
+	}
+	for key := range before.env {
+		if _, ok := r.env[key]; !ok {
+			envLeaks = append(envLeaks, fmt.Sprintf("environment variable %s unset, was %q", key, before.env[key]))
+		}
+	}
+	sort.Strings(envLeaks)
+	leaks = append(leaks, envLeaks...)
+
+	// The temporary directory itself may have been changed through TMPDIR,
+	// which is already reported.
+	if r.temp != nil && before.temp != nil && r.tempDir == before.tempDir {
+		var temp []string
+		for name := range r.temp {
+			if !before.temp[name] {
+				temp = append(temp, name)
+			}
+		}
+		sort.Strings(temp)
+		for _, name := range temp {
+			leaks = append(leaks, fmt.Sprintf("temporary file left behind: %s", filepath.Join(r.tempDir, name)))
+		}
+	}
+	return leaks
+}
+
+func ignoredFD(target string) bool {
+	for _, ignored := range ignoredFDs {
+		if target == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// useSuiteTempDir points TMPDIR to a temporary directory of its own for the
+// run of the suite, if it checks the resources leaked by its tests, so that
+// the temporary files left behind by its tests can be told apart from those
+// of the other processes. TMPDIR is restored once the suite is done.
+func (s *Suite[T, G]) useSuiteTempDir() {
+	checkResources, ok := any(s.suite).(CheckResources)
+	if !ok || !checkResources.CheckResources() {
+		return
+	}
+
+	dir := s.tb.TempDir()
+	s.restoreEnv("TMPDIR")
+	if err := os.Setenv("TMPDIR", dir); err != nil {
+		s.tb.Fatalf("CheckResources: %v", err)
+	}
+	// On some systems, like Windows, the temporary directory isn't given by
+	// TMPDIR, in which case the temporary files aren't checked.
+	if os.TempDir() == dir {
+		s.tempDir = dir
+	}
+}
+
+// checkResources snapshots the state of the process, if the suite checks the
+// resources leaked by its tests. Once the test and all of its cleanup
+// functions are done, it fails the test if it left file descriptors open,
+// changed the working directory or the environment, or left temporary files
+// in the temporary directory of the suite, given by suite, outside of
+// [Suite.TempDir]. Since these are shared by the whole process, parallel tests
+// aren't checked. It should be called before the setup hooks and before
+// registering the cleanup functions that may release resources.
+func (s *Suite[T, G]) checkResources(suite *Suite[T, G]) {
+	checkResources, ok := any(s.suite).(CheckResources)
+	if !ok || !checkResources.CheckResources() {
+		return
+	}
+
+	before := snapshotResources(suite.tempDir)
+	s.Cleanup(func() {
+		if s.parallel {
+			return
+		}
+
+		var leaks []string
+		deadline := time.Now().Add(resourceTimeout)
+		for wait := time.Millisecond; ; wait *= 2 {
+			leaks = snapshotResources(suite.tempDir).leaks(before)
+			if len(leaks) == 0 || time.Now().After(deadline) {
+				break
+			}
+			if wait > 100*time.Millisecond {
+				wait = 100 * time.Millisecond
+			}
+			time.Sleep(wait)
+		}
+		if len(leaks) > 0 {
+			s.tb.Errorf("the test leaked resources:\n  %s", strings.Join(leaks, "\n  "))
+		}
+	})
+}
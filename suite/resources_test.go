@@ -0,0 +1,111 @@
+package suite_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// resourceSuite is intended to test that the resources leaked by the tests
+// are reported against them.
+type resourceSuite struct {
+	*suite.Suite[resourceSuite, resourceSuiteGlobalData]
+}
+
+type resourceSuiteGlobalData struct{}
+
+var (
+	// leakedFiles are closed once the suite is done.
+	leakedFiles []*os.File
+
+	// systemTempDir is the temporary directory shared with the other
+	// processes.
+	systemTempDir string
+)
+
+func (s *resourceSuite) CheckResources() bool {
+	return true
+}
+
+func (s *resourceSuite) TestClean() {
+	s.Setenv("TESTIFY_RESOURCES_SETENV", "1")
+	f, err := os.Create(filepath.Join(s.TempDir(), "file"))
+	s.Require().NoError(err)
+	s.Cleanup(func() { f.Close() })
+
+	// The files written by the other processes to the temporary directory of
+	// the system aren't reported.
+	s.Require().NoError(os.WriteFile(filepath.Join(systemTempDir, "other-process"), nil, 0o600))
+}
+
+func (s *resourceSuite) TestOpenFile() {
+	f, err := os.Create(filepath.Join(s.TempDir(), "open"))
+	s.Require().NoError(err)
+	leakedFiles = append(leakedFiles, f)
+}
+
+func (s *resourceSuite) TestChdir() {
+	s.Require().NoError(os.Chdir(os.TempDir()))
+}
+
+func (s *resourceSuite) TestEnv() {
+	s.Require().NoError(os.Setenv("TESTIFY_RESOURCES_LEAK", "leaked"))
+}
+
+func (s *resourceSuite) TestTempFile() {
+	f, err := os.CreateTemp("", "leftover")
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+}
+
+func (s *resourceSuite) TestParallel() {
+	s.Parallel()
+	s.Require().NoError(os.Setenv("TESTIFY_RESOURCES_PARALLEL", "1"))
+}
+
+func TestSuiteResourceLeaks(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	systemTempDir = t.TempDir()
+	t.Setenv("TMPDIR", systemTempDir)
+	t.Cleanup(func() {
+		assert.NoError(t, os.Chdir(wd))
+		os.Unsetenv("TESTIFY_RESOURCES_LEAK")
+		os.Unsetenv("TESTIFY_RESOURCES_PARALLEL")
+		for _, f := range leakedFiles {
+			f.Close()
+		}
+		leakedFiles = nil
+	})
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/resourceSuite",
+		F: func(t *testing.T) {
+			suite.Run[resourceSuite, resourceSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NotContains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestClean ")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestParallel ")
+	if runtime.GOOS == "linux" {
+		assert.Contains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestOpenFile ")
+		assert.Regexp(t, `file descriptor \d+ left open: \S+/open( \(deleted\))?\n`, output)
+	}
+	assert.Contains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestChdir ")
+	assert.Regexp(t, `working directory changed from \S+ to \S+\n`, output)
+	assert.Contains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestEnv ")
+	assert.Contains(t, output, `environment variable TESTIFY_RESOURCES_LEAK set to "leaked", was unset`)
+	assert.Contains(t, output, "--- FAIL: TestSuiteResourceLeaks/resourceSuite/TestTempFile ")
+	assert.Regexp(t, `temporary file left behind: \S+/leftover\d+\n`, output)
+}
@@ -0,0 +1,140 @@
+package suite_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// setupErrorsSuite is intended to test that errors returned from the
+// error-returning setup hooks fail or skip the suite/test/subtest and that
+// the teardown hooks still run.
+type setupErrorsSuite struct {
+	*suite.Suite[setupErrorsSuite, setupErrorsSuiteGlobalData]
+}
+
+type setupErrorsSuiteGlobalData struct{}
+
+var (
+	setupErrorsCalls   []string
+	setupErrorsCallsMu sync.Mutex
+	setupErrorsStats   *suite.SuiteInformation
+)
+
+func (s *setupErrorsSuite) call(method string) {
+	setupErrorsCallsMu.Lock()
+	defer setupErrorsCallsMu.Unlock()
+	setupErrorsCalls = append(setupErrorsCalls, fmt.Sprintf("%s:%s", method, s.Name()))
+}
+
+func (s *setupErrorsSuite) SetupSuiteE() error {
+	if strings.Contains(s.Name(), "/FailSetupSuite") {
+		return errors.New("database is down")
+	}
+	return nil
+}
+
+func (s *setupErrorsSuite) HandleStats(_ string, stats *suite.SuiteInformation) {
+	if strings.HasSuffix(s.Name(), "/FailSetupTest") {
+		setupErrorsStats = stats
+	}
+}
+
+func (s *setupErrorsSuite) TearDownSuite() {
+	s.call("TearDownSuite")
+}
+
+func (s *setupErrorsSuite) SetupTestE() error {
+	switch {
+	case strings.HasSuffix(s.Name(), "/TestFailSetup"):
+		return errors.New("fixture not found")
+	case strings.HasSuffix(s.Name(), "/TestSkipSetup"):
+		return fmt.Errorf("%w: docker is not available", suite.ErrSkip)
+	}
+	return nil
+}
+
+func (s *setupErrorsSuite) TearDownTest() {
+	s.call("TearDownTest")
+}
+
+func (s *setupErrorsSuite) SetupSubTestE() error {
+	if strings.HasSuffix(s.Name(), "/fail") {
+		return errors.New("subtest fixture not found")
+	}
+	return nil
+}
+
+func (s *setupErrorsSuite) TearDownSubTest() {
+	s.call("TearDownSubTest")
+}
+
+func (s *setupErrorsSuite) TestFailSetup() {
+	s.call("Test")
+}
+
+func (s *setupErrorsSuite) TestSkipSetup() {
+	s.call("Test")
+}
+
+func (s *setupErrorsSuite) TestSubTests() {
+	s.Run("fail", func(s *setupErrorsSuite) { s.call("Test") })
+	s.Run("pass", func(s *setupErrorsSuite) { s.call("Test") })
+}
+
+func TestSuiteSetupErrors(t *testing.T) {
+	setupErrorsCalls = nil
+	setupErrorsStats = nil
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{
+		{
+			Name: t.Name() + "/FailSetupSuite",
+			F:    func(t *testing.T) { suite.Run[setupErrorsSuite, setupErrorsSuiteGlobalData](t) },
+		},
+		{
+			Name: t.Name() + "/FailSetupTest",
+			F:    func(t *testing.T) { suite.Run[setupErrorsSuite, setupErrorsSuiteGlobalData](t) },
+		},
+	})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "SetupSuite: database is down")
+	assert.Contains(t, output, "SetupTest: fixture not found")
+	assert.Contains(t, output, "SetupSubTest: subtest fixture not found")
+	assert.NotContains(t, output, "panic")
+
+	require.NotNil(t, setupErrorsStats)
+	require.Contains(t, setupErrorsStats.TestStats, "TestSkipSetup")
+	assert.True(t, setupErrorsStats.TestStats["TestSkipSetup"].Skipped)
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "SetupTest: skipped: docker is not available",
+		Condition: "SetupTestE",
+	}, setupErrorsStats.TestStats["TestSkipSetup"].Skip)
+
+	name := t.Name()
+	// The calls are made once per run of the suite, which happens more than
+	// once with -count=X where X > 1.
+	callOrderAssert(t, []string{
+		// The suite fails before any of the tests are run.
+		"TearDownSuite:" + name + "/FailSetupSuite",
+
+		"TearDownTest:" + name + "/FailSetupTest/TestFailSetup",
+		"TearDownTest:" + name + "/FailSetupTest/TestSkipSetup",
+		"TearDownSubTest:" + name + "/FailSetupTest/TestSubTests/fail",
+		"Test:" + name + "/FailSetupTest/TestSubTests/pass",
+		"TearDownSubTest:" + name + "/FailSetupTest/TestSubTests/pass",
+		"TearDownTest:" + name + "/FailSetupTest/TestSubTests",
+		"TearDownSuite:" + name + "/FailSetupTest",
+	}, setupErrorsCalls)
+}
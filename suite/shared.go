@@ -0,0 +1,97 @@
+package suite
+
+import (
+	"sync"
+)
+
+// concurrencySafe is implemented by the types that are safe to be modified
+// concurrently by parallel tests. Fields of these types are allowed to change
+// even when the global data is read-only.
+type concurrencySafe interface {
+	concurrencySafe()
+}
+
+// Shared holds a value that can be safely accessed by parallel tests. It is
+// meant to be stored in the global data of a suite. The zero value is ready
+// to use.
+type Shared[V any] struct {
+	mu sync.RWMutex
+	v  V
+}
+
+// NewShared returns a Shared holding v.
+func NewShared[V any](v V) *Shared[V] {
+	return &Shared[V]{v: v}
+}
+
+func (s *Shared[V]) concurrencySafe() {}
+
+// Read calls f with the value while holding a read lock. f must not modify
+// anything that the value refers to.
+func (s *Shared[V]) Read(f func(v V)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(s.v)
+}
+
+// Update calls f with a pointer to the value while holding a write lock.
+func (s *Shared[V]) Update(f func(v *V)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(&s.v)
+}
+
+// Lock locks the value for writing and returns a pointer to it, which is only
+// valid until the returned unlock function is called.
+func (s *Shared[V]) Lock() (v *V, unlock func()) {
+	s.mu.Lock()
+	return &s.v, s.mu.Unlock
+}
+
+// Load returns a (shallow) copy of the value.
+func (s *Shared[V]) Load() V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.v
+}
+
+// Store replaces the value.
+func (s *Shared[V]) Store(v V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.v = v
+}
+
+// Counter is a counter that can be safely updated by parallel tests. It is
+// meant to be stored in the global data of a suite. The zero value is ready
+// to use.
+//
+// The counter is guarded by a mutex rather than updated with the 64-bit
+// functions of sync/atomic, which require 64-bit alignment that isn't
+// guaranteed on 32-bit platforms for a Counter embedded in the global data.
+type Counter struct {
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *Counter) concurrencySafe() {}
+
+// Add adds delta to the counter and returns the new value.
+func (c *Counter) Add(delta int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n += delta
+	return c.n
+}
+
+// Inc increments the counter by one and returns the new value.
+func (c *Counter) Inc() int64 {
+	return c.Add(1)
+}
+
+// Load returns the current value of the counter.
+func (c *Counter) Load() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
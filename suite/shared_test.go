@@ -0,0 +1,238 @@
+package suite_test
+
+import (
+	"flag"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+func TestShared(t *testing.T) {
+	shared := suite.NewShared(map[string]int{})
+	var counter suite.Counter
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.Inc()
+			shared.Update(func(v *map[string]int) { (*v)["hits"]++ })
+			shared.Read(func(v map[string]int) { assert.Positive(t, v["hits"]) })
+			v, unlock := shared.Lock()
+			(*v)["locked"]++
+			unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(10), counter.Load())
+	assert.Equal(t, int64(15), counter.Add(5))
+	assert.Equal(t, map[string]int{"hits": 10, "locked": 10}, shared.Load())
+
+	shared.Store(nil)
+	assert.Nil(t, shared.Load())
+}
+
+// readOnlyGlobalSuite is intended to test that modifications of read-only
+// global data are reported against the test that made them.
+type readOnlyGlobalSuite struct {
+	*suite.Suite[readOnlyGlobalSuite, readOnlyGlobalData]
+}
+
+type readOnlyGlobalData struct {
+	Name    string
+	Config  struct{ Retries int }
+	Hits    suite.Counter
+	Cache   *suite.Shared[map[string]string]
+	Scratch []string `testify:"mutable"`
+}
+
+func (s *readOnlyGlobalSuite) ReadOnlyG() bool {
+	return true
+}
+
+func (s *readOnlyGlobalSuite) SetupSuite() {
+	s.G().Name = "suite"
+	s.G().Config.Retries = 3
+	s.G().Cache = suite.NewShared(map[string]string{})
+}
+
+func (s *readOnlyGlobalSuite) TestModifiesSafeValues() {
+	s.G().Hits.Inc()
+	s.G().Cache.Update(func(v *map[string]string) { (*v)["key"] = "value" })
+	s.G().Scratch = append(s.G().Scratch, "scratch")
+}
+
+func (s *readOnlyGlobalSuite) TestModifiesGlobalData() {
+	s.Cleanup(func() { s.G().Config.Retries = 5 })
+	s.G().Name = "modified"
+}
+
+func TestSuiteReadOnlyGlobal(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/readOnlyGlobalSuite",
+		F: func(t *testing.T) {
+			suite.Run[readOnlyGlobalSuite, readOnlyGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuiteReadOnlyGlobal/readOnlyGlobalSuite/TestModifiesGlobalData")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteReadOnlyGlobal/readOnlyGlobalSuite/TestModifiesSafeValues")
+	assert.Contains(t, output, "global data is read-only but was modified while the test was running:")
+	assert.Contains(t, output, "readOnlyGlobalData.Config.Retries: 3 -> 5")
+	assert.Contains(t, output, `readOnlyGlobalData.Name: "suite" -> "modified"`)
+	assert.NotContains(t, output, "readOnlyGlobalData.Scratch")
+}
+
+// parallelReadOnlySuite is intended to test that the modifications of
+// read-only global data made by parallel tests are reported against the
+// suite, naming the tests that may have made them.
+type parallelReadOnlySuite struct {
+	*suite.Suite[parallelReadOnlySuite, parallelReadOnlyData]
+}
+
+type parallelReadOnlyData struct {
+	Before, During int
+	started        chan struct{} // signaled by the other tests once they run in parallel
+	mutated        chan struct{} // closed by the mutator once it modified the global data
+}
+
+func (s *parallelReadOnlySuite) ReadOnlyG() bool {
+	return true
+}
+
+func (s *parallelReadOnlySuite) SetupSuite() {
+	s.G().started = make(chan struct{}, 2)
+	s.G().mutated = make(chan struct{})
+}
+
+func (s *parallelReadOnlySuite) TestBystander() {
+	s.Parallel()
+	s.G().started <- struct{}{}
+	<-s.G().mutated
+}
+
+func (s *parallelReadOnlySuite) TestMutatesBeforeParallel() {
+	s.G().Before = 1
+	s.Parallel()
+	s.G().started <- struct{}{}
+	<-s.G().mutated
+}
+
+func (s *parallelReadOnlySuite) TestMutator() {
+	s.Parallel()
+	// The global data is modified while all three tests are running.
+	<-s.G().started
+	<-s.G().started
+	s.G().During = 1
+	close(s.G().mutated)
+}
+
+func TestSuiteReadOnlyGlobalParallel(t *testing.T) {
+	// The three tests have to run at the same time.
+	parallel := flag.Lookup("test.parallel").Value.String()
+	require.NoError(t, flag.Set("test.parallel", "4"))
+	defer func() { require.NoError(t, flag.Set("test.parallel", parallel)) }()
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/parallelReadOnlySuite",
+		F: func(t *testing.T) {
+			suite.Run[parallelReadOnlySuite, parallelReadOnlyData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The modification made before the test was paused is attributed to it
+	// alone.
+	assert.Contains(t, output, "--- FAIL: TestSuiteReadOnlyGlobalParallel/parallelReadOnlySuite/TestMutatesBeforeParallel")
+	assert.Equal(t,
+		strings.Count(output, "--- FAIL: TestSuiteReadOnlyGlobalParallel/parallelReadOnlySuite/TestMutatesBeforeParallel"),
+		strings.Count(output, "parallelReadOnlyData.Before: 0 -> 1"))
+
+	// The one made while running in parallel is attributed to all the tests
+	// running at the same time, and fails the suite.
+	assert.NotContains(t, output, "--- FAIL: TestSuiteReadOnlyGlobalParallel/parallelReadOnlySuite/TestMutator")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteReadOnlyGlobalParallel/parallelReadOnlySuite/TestBystander")
+	assert.Regexp(t, `modified by one of the tests running in parallel \(TestBystander, TestMutatesBeforeParallel, TestMutator\):\n\s+parallelReadOnlyData.During: 0 -> 1\n`, output)
+}
+
+// freezeGlobalSuite is intended to test that frozen global data is checked
+// deeply, through pointers, maps and slices.
+type freezeGlobalSuite struct {
+	*suite.Suite[freezeGlobalSuite, freezeGlobalData]
+}
+
+type freezeGlobalData struct {
+	mu     sync.Mutex
+	Config *freezeConfig
+	Pool   *freezePool `testify:"shallow"`
+}
+
+type freezeConfig struct {
+	Endpoints []string
+	Limits    map[string]int
+	Parent    *freezeConfig
+}
+
+type freezePool struct {
+	InUse int
+}
+
+func (s *freezeGlobalSuite) FreezeG() bool {
+	return true
+}
+
+func (s *freezeGlobalSuite) SetupSuite() {
+	s.G().Config = &freezeConfig{
+		Endpoints: []string{"a", "b"},
+		Limits:    map[string]int{"rps": 10},
+	}
+	s.G().Config.Parent = s.G().Config
+	s.G().Pool = &freezePool{}
+}
+
+func (s *freezeGlobalSuite) TestUsesGlobalData() {
+	s.G().mu.Lock()
+	defer s.G().mu.Unlock()
+	s.G().Pool.InUse++
+}
+
+func (s *freezeGlobalSuite) TestModifiesNestedData() {
+	s.G().Config.Limits["rps"] = 20
+	s.G().Config.Endpoints[1] = "c"
+}
+
+func TestSuiteFreezeGlobal(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/freezeGlobalSuite",
+		F: func(t *testing.T) {
+			suite.Run[freezeGlobalSuite, freezeGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuiteFreezeGlobal/freezeGlobalSuite/TestModifiesNestedData")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteFreezeGlobal/freezeGlobalSuite/TestUsesGlobalData")
+	assert.Contains(t, output, "freezeGlobalData.Config.Limits[rps]: 10 -> 20")
+	assert.Contains(t, output, `freezeGlobalData.Config.Endpoints[1]: "b" -> "c"`)
+	assert.NotContains(t, output, "freezeGlobalData.Pool")
+}
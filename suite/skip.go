@@ -0,0 +1,149 @@
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// suiteSkipped is panicked by [Suite.SkipSuite] to stop the execution of
+// SetupSuite. It is recovered by [Suite.setupSuite].
+type suiteSkipped struct {
+	reason string
+}
+
+// SkipSuite skips the whole suite. It can only be called from SetupSuite or
+// SetupSuiteE. It stops the execution of the setup hook, runs the suite
+// teardown hooks and then marks every test in the suite as skipped with the
+// given reason, without running any of the per-test hooks.
+func (s *Suite[T, G]) SkipSuite(reason string) {
+	if s.currentPhase() != PhaseSetupSuite {
+		s.tb.Fatalf("SkipSuite can only be called from SetupSuite")
+	}
+	panic(suiteSkipped{reason: reason})
+}
+
+// setupSuite runs the suite setup hooks. It returns a non-nil [SkipInfo] if
+// the suite was skipped from within SetupSuite, or if SetupSuiteE returned an
+// error wrapping [ErrSkip].
+func (s *Suite[T, G]) setupSuite() (skip *SkipInfo, err error) {
+	s.setPhase(PhaseSetupSuite)
+	defer func() {
+		s.setPhase("")
+		if r := recover(); r != nil {
+			skipped, ok := r.(suiteSkipped)
+			if !ok {
+				panic(r)
+			}
+			skip = &SkipInfo{Reason: skipped.reason, Condition: "SkipSuite"}
+		}
+	}()
+
+	if setupAllSuite, ok := any(s.suite).(SetupAllSuite); ok {
+		setupAllSuite.SetupSuite()
+	}
+	if setupAllSuiteE, ok := any(s.suite).(SetupAllSuiteE); ok {
+		err = setupAllSuiteE.SetupSuiteE()
+	}
+	// Skipping the suite from SetupSuiteE is the same as calling SkipSuite.
+	if errors.Is(err, ErrSkip) {
+		return &SkipInfo{Reason: fmt.Sprintf("%s: %v", PhaseSetupSuite, err), Condition: "SetupSuiteE"}, nil
+	}
+	return skip, err
+}
+
+// registerTearDownSuite registers the suite teardown hooks to run once all the
+// tests in the suite are done. [T.Cleanup], unlike defer, ensures that this is
+// the case even for parallel tests.
+//
+// It must be called after [Suite.setupSuite] because we want [TearDownAllSuite]
+// to run before any cleanup functions registered within [SetupAllSuite].
+func (s *Suite[T, G]) registerTearDownSuite() {
+	if tearDownAllSuite, ok := any(s.suite).(TearDownAllSuite); ok {
+		s.Cleanup(func() {
+			s.runTeardown(PhaseTearDownSuite, func() error {
+				tearDownAllSuite.TearDownSuite()
+				return nil
+			})
+		})
+	}
+	if tearDownAllSuiteE, ok := any(s.suite).(TearDownAllSuiteE); ok {
+		s.Cleanup(func() {
+			s.runTeardown(PhaseTearDownSuite, tearDownAllSuiteE.TearDownSuiteE)
+		})
+	}
+}
+
+// skipWith skips the current test and records why in the suite stats.
+func (s *Suite[T, G]) skipWith(condition, value, reason string) {
+	s.tb.Helper()
+	s.skip = &SkipInfo{Reason: reason, Condition: condition, Value: value}
+	s.tb.Skip(reason)
+}
+
+// SkipUnlessEnv skips the current test unless all of the given environment
+// variables are set to a non-empty value.
+func (s *Suite[T, G]) SkipUnlessEnv(keys ...string) {
+	s.tb.Helper()
+	for _, key := range keys {
+		if os.Getenv(key) == "" {
+			s.skipWith("SkipUnlessEnv", key, fmt.Sprintf("environment variable %s is not set", key))
+		}
+	}
+}
+
+// SkipIfShort skips the current test if the -short flag is set.
+func (s *Suite[T, G]) SkipIfShort() {
+	s.tb.Helper()
+	if testing.Short() {
+		s.skipWith("SkipIfShort", "", "skipping in short mode")
+	}
+}
+
+// SkipUnlessBinary skips the current test unless all of the given binaries
+// can be found in the directories named by the PATH environment variable.
+func (s *Suite[T, G]) SkipUnlessBinary(names ...string) {
+	s.tb.Helper()
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err != nil {
+			s.skipWith("SkipUnlessBinary", name, fmt.Sprintf("binary %s is not available", name))
+		}
+	}
+}
+
+// SkipOnGOOS skips the current test when running on any of the given
+// operating systems.
+func (s *Suite[T, G]) SkipOnGOOS(goos ...string) {
+	s.tb.Helper()
+	for _, name := range goos {
+		if runtime.GOOS == name {
+			s.skipWith("SkipOnGOOS", name, fmt.Sprintf("skipping on GOOS=%s", name))
+		}
+	}
+}
+
+// SkipOnGOARCH skips the current test when running on any of the given
+// architectures.
+func (s *Suite[T, G]) SkipOnGOARCH(goarch ...string) {
+	s.tb.Helper()
+	for _, name := range goarch {
+		if runtime.GOARCH == name {
+			s.skipWith("SkipOnGOARCH", name, fmt.Sprintf("skipping on GOARCH=%s", name))
+		}
+	}
+}
+
+// SkipUntil skips the current test until the given date has passed. It is
+// meant for temporarily disabling a known broken test without forgetting
+// about it.
+func (s *Suite[T, G]) SkipUntil(date time.Time, reason string) {
+	s.tb.Helper()
+	if time.Now().Before(date) {
+		value := date.Format(time.RFC3339)
+		s.skipWith("SkipUntil", value, fmt.Sprintf("skipped until %s: %s", value, reason))
+	}
+}
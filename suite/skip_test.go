@@ -0,0 +1,235 @@
+package suite_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// suiteSkipTester is intended to test that a suite skipped from SetupSuite
+// runs its teardown but none of the per-test hooks and reports every test
+// as skipped.
+type suiteSkipTester struct {
+	*suite.Suite[suiteSkipTester, suiteSkipTesterGlobalData]
+}
+
+type suiteSkipTesterGlobalData struct{}
+
+var (
+	suiteSkipCalls []string
+	suiteSkipStats *suite.SuiteInformation
+)
+
+func (s *suiteSkipTester) SetupSuite() {
+	suiteSkipCalls = append(suiteSkipCalls, "SetupSuite")
+	s.SkipSuite("database is not available")
+	suiteSkipCalls = append(suiteSkipCalls, "SetupSuite after SkipSuite")
+}
+
+func (s *suiteSkipTester) TearDownSuite() {
+	suiteSkipCalls = append(suiteSkipCalls, "TearDownSuite")
+}
+
+func (s *suiteSkipTester) SetupTest() {
+	suiteSkipCalls = append(suiteSkipCalls, "SetupTest")
+}
+
+func (s *suiteSkipTester) HandleStats(_ string, stats *suite.SuiteInformation) {
+	suiteSkipStats = stats
+}
+
+func (s *suiteSkipTester) TestOne() {
+	suiteSkipCalls = append(suiteSkipCalls, "TestOne")
+}
+
+func (s *suiteSkipTester) TestTwo() {
+	suiteSkipCalls = append(suiteSkipCalls, "TestTwo")
+}
+
+// shouldSkipTester is intended to test that a suite skipped by ShouldSkip is
+// not even setup.
+type shouldSkipTester struct {
+	*suite.Suite[shouldSkipTester, shouldSkipTesterGlobalData]
+}
+
+type shouldSkipTesterGlobalData struct{}
+
+func (s *shouldSkipTester) ShouldSkip() (bool, string) {
+	return true, "requires linux"
+}
+
+func (s *shouldSkipTester) SetupSuite() {
+	panic("should never be called because the suite is skipped")
+}
+
+func (s *shouldSkipTester) TearDownSuite() {
+	panic("should never be called because the suite is skipped")
+}
+
+func (s *shouldSkipTester) TestOne() {
+	panic("should never be called because the suite is skipped")
+}
+
+// setupSkipTester is intended to test that a suite skipped by returning
+// ErrSkip from SetupSuiteE is reported like one skipped by SkipSuite.
+type setupSkipTester struct {
+	*suite.Suite[setupSkipTester, setupSkipTesterGlobalData]
+}
+
+type setupSkipTesterGlobalData struct{}
+
+var setupSkipStats *suite.SuiteInformation
+
+func (s *setupSkipTester) SetupSuiteE() error {
+	return fmt.Errorf("%w: docker is not available", suite.ErrSkip)
+}
+
+func (s *setupSkipTester) SetupTest() {
+	panic("should never be called because the suite is skipped")
+}
+
+func (s *setupSkipTester) HandleStats(_ string, stats *suite.SuiteInformation) {
+	setupSkipStats = stats
+}
+
+func (s *setupSkipTester) TestOne() {
+	panic("should never be called because the suite is skipped")
+}
+
+func TestSuiteSkip(t *testing.T) {
+	suiteSkipCalls = nil
+	suiteSkipStats = nil
+	setupSkipStats = nil
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{
+		{
+			Name: t.Name() + "/suiteSkipTester",
+			F: func(t *testing.T) {
+				suite.Run[suiteSkipTester, suiteSkipTesterGlobalData](t)
+			},
+		},
+		{
+			Name: t.Name() + "/shouldSkipTester",
+			F: func(t *testing.T) {
+				suite.Run[shouldSkipTester, shouldSkipTesterGlobalData](t)
+			},
+		},
+		{
+			Name: t.Name() + "/setupSkipTester",
+			F: func(t *testing.T) {
+				suite.Run[setupSkipTester, setupSkipTesterGlobalData](t)
+			},
+		},
+	})
+	assert.True(t, ok)
+
+	callOrderAssert(t, []string{"SetupSuite", "TearDownSuite"}, suiteSkipCalls)
+
+	require.NotNil(t, suiteSkipStats)
+	assert.Equal(t, &suite.SkipInfo{Reason: "database is not available", Condition: "SkipSuite"}, suiteSkipStats.Skip)
+	assert.Len(t, suiteSkipStats.TestStats, 2)
+	for _, name := range []string{"TestOne", "TestTwo"} {
+		require.Contains(t, suiteSkipStats.TestStats, name)
+		assert.True(t, suiteSkipStats.TestStats[name].Skipped)
+		assert.Equal(t, suiteSkipStats.Skip, suiteSkipStats.TestStats[name].Skip)
+	}
+
+	require.NotNil(t, setupSkipStats)
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "SetupSuite: skipped: docker is not available",
+		Condition: "SetupSuiteE",
+	}, setupSkipStats.Skip)
+	require.Contains(t, setupSkipStats.TestStats, "TestOne")
+	assert.True(t, setupSkipStats.TestStats["TestOne"].Skipped)
+	assert.Equal(t, setupSkipStats.Skip, setupSkipStats.TestStats["TestOne"].Skip)
+}
+
+// skipHelpersTester is intended to test that the conditional skip helpers
+// skip the test and record why in the stats.
+type skipHelpersTester struct {
+	*suite.Suite[skipHelpersTester, skipHelpersTesterGlobalData]
+}
+
+type skipHelpersTesterGlobalData struct{}
+
+var skipHelpersStats *suite.SuiteInformation
+
+func (s *skipHelpersTester) HandleStats(_ string, stats *suite.SuiteInformation) {
+	skipHelpersStats = stats
+}
+
+func (s *skipHelpersTester) TestSkipUnlessEnv() {
+	s.SkipUnlessEnv("PATH", "TESTIFY_SKIP_HELPERS_UNSET")
+	s.Fail("should have been skipped")
+}
+
+func (s *skipHelpersTester) TestSkipUnlessBinary() {
+	s.SkipUnlessBinary("testify-no-such-binary")
+	s.Fail("should have been skipped")
+}
+
+func (s *skipHelpersTester) TestSkipOnGOOS() {
+	s.SkipOnGOOS(runtime.GOOS)
+	s.Fail("should have been skipped")
+}
+
+func (s *skipHelpersTester) TestSkipUntil() {
+	s.SkipUntil(time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC), "flaky")
+	s.Fail("should have been skipped")
+}
+
+func (s *skipHelpersTester) TestSkip() {
+	s.Skip("not", "implemented")
+}
+
+func (s *skipHelpersTester) TestNotSkipped() {
+	s.SkipUntil(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "flaky")
+	s.SkipOnGOARCH("testify-no-such-arch")
+	s.SkipUnlessEnv("PATH")
+}
+
+func TestSuiteSkipHelpers(t *testing.T) {
+	skipHelpersStats = nil
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/skipHelpersTester",
+		F: func(t *testing.T) {
+			suite.Run[skipHelpersTester, skipHelpersTesterGlobalData](t)
+		},
+	}})
+	assert.True(t, ok)
+
+	require.NotNil(t, skipHelpersStats)
+	stats := skipHelpersStats.TestStats
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "environment variable TESTIFY_SKIP_HELPERS_UNSET is not set",
+		Condition: "SkipUnlessEnv",
+		Value:     "TESTIFY_SKIP_HELPERS_UNSET",
+	}, stats["TestSkipUnlessEnv"].Skip)
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "binary testify-no-such-binary is not available",
+		Condition: "SkipUnlessBinary",
+		Value:     "testify-no-such-binary",
+	}, stats["TestSkipUnlessBinary"].Skip)
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "skipping on GOOS=" + runtime.GOOS,
+		Condition: "SkipOnGOOS",
+		Value:     runtime.GOOS,
+	}, stats["TestSkipOnGOOS"].Skip)
+	assert.Equal(t, &suite.SkipInfo{
+		Reason:    "skipped until 2999-01-01T00:00:00Z: flaky",
+		Condition: "SkipUntil",
+		Value:     "2999-01-01T00:00:00Z",
+	}, stats["TestSkipUntil"].Skip)
+	assert.Equal(t, &suite.SkipInfo{Reason: "not implemented"}, stats["TestSkip"].Skip)
+
+	for name, info := range stats {
+		assert.Equal(t, name != "TestNotSkipped", info.Skipped, name)
+	}
+}
@@ -0,0 +1,328 @@
+package suite
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+const snapshotHeader = "=== "
+
+// snapshotConfig formats the values of the snapshots so that they only depend
+// on the content of the values.
+var snapshotConfig = spew.ConfigState{
+	Indent:                  "  ",
+	SortKeys:                true,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+}
+
+// snapshotFiles holds the snapshot files loaded so far, keyed by path, so that
+// each file is only loaded once even if its suite is run more than once.
+var (
+	snapshotFiles   = map[string]*snapshotFile{}
+	snapshotFilesMu sync.Mutex
+)
+
+// snapshotFile holds the snapshots of a suite, keyed by the full name of the
+// test that took them and their number within that test, e.g.
+// "TestEntryPoint/TestOne/sub1 2".
+type snapshotFile struct {
+	mu      sync.Mutex
+	path    string
+	loaded  bool
+	err     error
+	entries map[string]string
+	dirty   bool
+}
+
+func getSnapshotFile(path string) *snapshotFile {
+	snapshotFilesMu.Lock()
+	defer snapshotFilesMu.Unlock()
+	f, ok := snapshotFiles[path]
+	if !ok {
+		f = &snapshotFile{path: path}
+		snapshotFiles[path] = f
+	}
+	return f
+}
+
+// load reads the snapshot file, if it wasn't already. It must be called with
+// f.mu held.
+func (f *snapshotFile) load() error {
+	if f.loaded {
+		return f.err
+	}
+	f.loaded = true
+	f.entries = map[string]string{}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		f.err = err
+		return err
+	}
+
+	var key string
+	var value strings.Builder
+	flush := func() {
+		if key != "" {
+			f.entries[key] = strings.TrimRight(value.String(), "\n") + "\n"
+		}
+		value.Reset()
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, snapshotHeader) {
+			flush()
+			key = strings.TrimPrefix(line, snapshotHeader)
+			continue
+		}
+		if key != "" {
+			value.WriteString(line + "\n")
+		}
+	}
+	flush()
+	return nil
+}
+
+// write writes the snapshot file, sorted by key. It must be called with f.mu
+// held.
+func (f *snapshotFile) write() error {
+	keys := make([]string, 0, len(f.entries))
+	for key := range f.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessSnapshotKey(keys[i], keys[j]) })
+
+	var buf bytes.Buffer
+	buf.WriteString("# Snapshots of the testify suite, update them with -testify.update.\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\n%s%s\n%s", snapshotHeader, key, f.entries[key])
+	}
+	if len(keys) == 0 {
+		err := os.Remove(f.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return err
+	}
+	return writeGolden(f.path, buf.Bytes())
+}
+
+// lessSnapshotKey orders the keys by test name, then by number.
+func lessSnapshotKey(a, b string) bool {
+	nameA, nA := splitSnapshotKey(a)
+	nameB, nB := splitSnapshotKey(b)
+	if nameA != nameB {
+		return nameA < nameB
+	}
+	return nA < nB
+}
+
+func splitSnapshotKey(key string) (string, int) {
+	i := strings.LastIndexByte(key, ' ')
+	if i < 0 {
+		return key, 0
+	}
+	n, _ := strconv.Atoi(key[i+1:])
+	return key[:i], n
+}
+
+// snapshotRun tracks the snapshots taken by one run of a suite, to find the
+// obsolete ones once all of its tests are done.
+type snapshotRun struct {
+	file    *snapshotFile
+	root    string          // the name of the test running the suite
+	methods map[string]bool // the test methods of the suite
+
+	// Unless the -run or -skip flags select subtests, all the subtests of a
+	// test run along with it, so the snapshots of a test that passed that
+	// weren't taken again, e.g. by a subtest that was deleted, are obsolete.
+	filtered bool
+
+	mu      sync.Mutex
+	used    map[string]bool // the snapshots taken
+	passed  map[string]bool // the full names of the tests and subtests that passed
+	skipped map[string]bool // the full names of the tests and subtests that were skipped
+}
+
+func newSnapshotRun(suiteName, root string, methods map[string]bool) *snapshotRun {
+	path := filepath.Join("__snapshots__", suiteName+".snap")
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return &snapshotRun{
+		file:     getSnapshotFile(path),
+		root:     root,
+		methods:  methods,
+		filtered: subtestsFiltered(root),
+		used:     map[string]bool{},
+		passed:   map[string]bool{},
+		skipped:  map[string]bool{},
+	}
+}
+
+// subtestsFiltered reports whether the -run or -skip flags have patterns for
+// the subtests of the tests of the suite run by root, rather than only for
+// the tests.
+func subtestsFiltered(root string) bool {
+	depth := strings.Count(root, "/") + 2 // the number of elements of the names of the tests
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && len(strings.Split(f.Value.String(), "/")) > depth {
+			return true
+		}
+	}
+	return false
+}
+
+// pass records that the test or subtest with the given full name passed, so
+// that the snapshots it didn't take again are obsolete.
+func (r *snapshotRun) pass(test string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.passed[test] = true
+}
+
+// skip records that the test or subtest with the given full name was skipped,
+// so that its snapshots, and those of its subtests, are kept.
+func (r *snapshotRun) skip(test string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.skipped[test] = true
+}
+
+// take compares value against the snapshot key, or records it in update
+// mode. It returns the previous value of the snapshot, if any.
+func (r *snapshotRun) take(key, value string) (old string, ok bool, err error) {
+	r.mu.Lock()
+	r.used[key] = true
+	r.mu.Unlock()
+
+	r.file.mu.Lock()
+	defer r.file.mu.Unlock()
+	if err := r.file.load(); err != nil {
+		return "", false, err
+	}
+	old, ok = r.file.entries[key]
+	if *update && old != value {
+		r.file.entries[key] = value
+		r.file.dirty = true
+	}
+	return old, ok, nil
+}
+
+// finish returns the obsolete snapshots of the run: those taken by test
+// methods that don't exist anymore, or not taken again by tests that passed,
+// including their subtests. The snapshots of the tests that didn't run, e.g.
+// because of -run, or didn't pass are kept, as are those of the subtests that
+// were skipped. If -run or -skip select subtests, only the snapshots of the
+// subtests that passed themselves can be obsolete. In update mode, the
+// obsolete snapshots are removed and the snapshot file is written.
+func (r *snapshotRun) finish() ([]string, error) {
+	r.file.mu.Lock()
+	defer r.file.mu.Unlock()
+	if err := r.file.load(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var obsolete []string
+	for key := range r.file.entries {
+		name, _ := splitSnapshotKey(key)
+		if !strings.HasPrefix(name, r.root+"/") || r.used[key] {
+			continue
+		}
+		method := strings.SplitN(strings.TrimPrefix(name, r.root+"/"), "/", 2)[0]
+		if !r.methods[method] || r.passed[name] || (!r.filtered && r.passed[r.root+"/"+method] && !r.isSkipped(name)) {
+			obsolete = append(obsolete, key)
+		}
+	}
+	sort.Slice(obsolete, func(i, j int) bool { return lessSnapshotKey(obsolete[i], obsolete[j]) })
+
+	if !*update {
+		return obsolete, nil
+	}
+	for _, key := range obsolete {
+		delete(r.file.entries, key)
+		r.file.dirty = true
+	}
+	if !r.file.dirty {
+		return obsolete, nil
+	}
+	r.file.dirty = false
+	return obsolete, r.file.write()
+}
+
+// isSkipped reports whether the test or subtest with the given full name, or
+// one of its parents, was skipped. It must be called with r.mu held.
+func (r *snapshotRun) isSkipped(test string) bool {
+	for name := test; name != r.root; name = name[:strings.LastIndexByte(name, '/')] {
+		if r.skipped[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot asserts that value matches the snapshot taken by the previous runs
+// of the current test. Snapshots are stored in __snapshots__/<suite name>.snap,
+// keyed by the name of the test and the number of the call to Snapshot within
+// that test. When the tests are run with the -testify.update flag, the changed
+// snapshots are written instead.
+//
+// Once all the tests of the suite are done, the snapshots that are obsolete
+// are logged and listed in the stats of the suite. In update mode, they are
+// removed.
+func (s *Suite[T, G]) Snapshot(value any) bool {
+	s.tb.Helper()
+
+	if s.snapshots == nil {
+		s.tb.Errorf("Snapshot can only be used by the tests run by suite.Run")
+		return false
+	}
+
+	s.snapshotCount++
+	key := fmt.Sprintf("%s %d", s.tb.Name(), s.snapshotCount)
+	got := snapshotConfig.Sdump(value)
+
+	old, ok, err := s.snapshots.take(key, got)
+	switch {
+	case err != nil:
+		s.tb.Errorf("snapshot %s: %v", key, err)
+		return false
+	case ok && old == got:
+		return true
+	case *update:
+		s.tb.Logf("updated snapshot %s", key)
+		return true
+	case !ok:
+		s.tb.Errorf("snapshot %s does not exist, run the tests with -testify.update to create it", key)
+		return false
+	}
+	s.tb.Errorf("snapshot %s does not match, run the tests with -testify.update to update it:\n%s",
+		key, goldenDiff([]byte(old), []byte(got), false))
+	return false
+}
@@ -0,0 +1,226 @@
+package suite_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// snapshotSuite is intended to test the creation, update, mismatches and
+// obsolescence of snapshots.
+type snapshotSuite struct {
+	*suite.Suite[snapshotSuite, snapshotSuiteGlobalData]
+}
+
+type snapshotSuiteGlobalData struct{}
+
+type snapshotUser struct {
+	Name  string
+	Tags  map[string]int
+	Admin *bool
+}
+
+var (
+	snapshotName    string
+	snapshotExtra   bool
+	snapshotDeleted bool
+	snapshotStats   *suite.SuiteInformation
+)
+
+func (s *snapshotSuite) HandleStats(_ string, stats *suite.SuiteInformation) {
+	snapshotStats = stats
+}
+
+func (s *snapshotSuite) TestUser() {
+	admin := true
+	s.Snapshot(snapshotUser{Name: snapshotName, Tags: map[string]int{"b": 2, "a": 1}, Admin: &admin})
+	s.Snapshot([]string{"second"})
+	s.Run("extra", func(s *snapshotSuite) {
+		if snapshotExtra {
+			s.Snapshot(42)
+		}
+	})
+	if !snapshotDeleted {
+		s.Run("deleted", func(s *snapshotSuite) {
+			s.Snapshot("deleted")
+		})
+	}
+}
+
+func TestSuiteSnapshot(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { require.NoError(t, os.Chdir(wd)) }()
+
+	run := func(update bool) (bool, string) {
+		require.NoError(t, flag.Set("testify.update", "false"))
+		if update {
+			require.NoError(t, flag.Set("testify.update", "true"))
+			defer func() { require.NoError(t, flag.Set("testify.update", "false")) }()
+		}
+
+		snapshotStats = nil
+		capture := StdoutCapture{}
+		capture.StartCapture()
+		ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+			Name: t.Name() + "/snapshotSuite",
+			F: func(t *testing.T) {
+				suite.Run[snapshotSuite, snapshotSuiteGlobalData](t)
+			},
+		}})
+		output, err := capture.StopCapture()
+		require.NoError(t, err)
+		return ok, output
+	}
+	path := filepath.Join("__snapshots__", "snapshotSuite.snap")
+	key := t.Name() + "/snapshotSuite/TestUser"
+
+	snapshotName, snapshotExtra = "gopher", true
+	ok, output := run(false)
+	assert.False(t, ok)
+	assert.Contains(t, output, "snapshot "+key+" 1 does not exist, run the tests with -testify.update to create it")
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "=== "+key+" 1\n")
+	assert.Contains(t, string(content), "=== "+key+" 2\n")
+	assert.Contains(t, string(content), "=== "+key+"/extra 1\n(int) 42\n")
+
+	ok, _ = run(false)
+	assert.True(t, ok)
+
+	snapshotName = "gophers"
+	ok, output = run(false)
+	assert.False(t, ok)
+	assert.Contains(t, output, "snapshot "+key+" 1 does not match, run the tests with -testify.update to update it:")
+	assert.Contains(t, output, `-  Name: (string) (len=6) "gopher",`)
+	assert.Contains(t, output, `+  Name: (string) (len=7) "gophers",`)
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+
+	snapshotExtra = false
+	ok, _ = run(false)
+	assert.True(t, ok)
+	require.NotNil(t, snapshotStats)
+	assert.Equal(t, []string{key + "/extra 1"}, snapshotStats.ObsoleteSnapshots)
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "extra")
+	assert.Contains(t, string(content), `"gophers"`)
+
+	ok, _ = run(false)
+	assert.True(t, ok)
+	require.NotNil(t, snapshotStats)
+	assert.Empty(t, snapshotStats.ObsoleteSnapshots)
+
+	// The snapshots of a subtest that was deleted from a test that still
+	// passes are obsolete as well.
+	snapshotDeleted = true
+	defer func() { snapshotDeleted = false }()
+	ok, _ = run(false)
+	assert.True(t, ok)
+	require.NotNil(t, snapshotStats)
+	assert.Equal(t, []string{key + "/deleted 1"}, snapshotStats.ObsoleteSnapshots)
+
+	ok, _ = run(true)
+	assert.True(t, ok)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "deleted")
+}
+
+// snapshotFilterSuite is intended to test that the snapshots of the subtests
+// that didn't run or didn't pass are never obsolete.
+type snapshotFilterSuite struct {
+	*suite.Suite[snapshotFilterSuite, snapshotFilterSuiteGlobalData]
+}
+
+type snapshotFilterSuiteGlobalData struct{}
+
+var (
+	snapshotFilterSkip  bool
+	snapshotFilterStats *suite.SuiteInformation
+)
+
+func (s *snapshotFilterSuite) HandleStats(_ string, stats *suite.SuiteInformation) {
+	snapshotFilterStats = stats
+}
+
+func (s *snapshotFilterSuite) TestOne() {
+	s.Run("a", func(s *snapshotFilterSuite) {
+		s.Snapshot("a")
+	})
+	s.Run("b", func(s *snapshotFilterSuite) {
+		if snapshotFilterSkip {
+			s.Skip("skipped")
+		}
+		s.Snapshot("b")
+	})
+}
+
+func TestSuiteSnapshotFilter(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { require.NoError(t, os.Chdir(wd)) }()
+
+	require.NoError(t, flag.Set("testify.update", "true"))
+	defer func() { require.NoError(t, flag.Set("testify.update", "false")) }()
+	testRun := flag.Lookup("test.run").Value.String()
+	defer func() { require.NoError(t, flag.Set("test.run", testRun)) }()
+
+	run := func(pattern string) {
+		require.NoError(t, flag.Set("test.run", pattern))
+		snapshotFilterStats = nil
+		capture := StdoutCapture{}
+		capture.StartCapture()
+		ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+			Name: t.Name() + "/snapshotFilterSuite",
+			F: func(t *testing.T) {
+				suite.Run[snapshotFilterSuite, snapshotFilterSuiteGlobalData](t)
+			},
+		}})
+		_, err := capture.StopCapture()
+		require.NoError(t, err)
+		assert.True(t, ok)
+		require.NotNil(t, snapshotFilterStats)
+		assert.Empty(t, snapshotFilterStats.ObsoleteSnapshots)
+	}
+	path := filepath.Join("__snapshots__", "snapshotFilterSuite.snap")
+	key := t.Name() + "/snapshotFilterSuite/TestOne"
+
+	snapshotFilterSkip = false
+	run("")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "=== "+key+"/a 1\n")
+	assert.Contains(t, string(content), "=== "+key+"/b 1\n")
+
+	// Only the subtest a runs.
+	run(key + "/a")
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "=== "+key+"/a 1\n")
+	assert.Contains(t, string(content), "=== "+key+"/b 1\n")
+
+	// The subtest b skips itself.
+	snapshotFilterSkip = true
+	defer func() { snapshotFilterSkip = false }()
+	run("")
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "=== "+key+"/b 1\n")
+}
@@ -0,0 +1,117 @@
+package suite
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// softFailure is a failure recorded by a soft assertion.
+type softFailure struct {
+	location string
+	message  string
+}
+
+// softCollector records the failures of the soft assertions instead of
+// reporting them to the test.
+type softCollector struct {
+	mu       sync.Mutex
+	failures []softFailure
+}
+
+func (c *softCollector) Errorf(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, softFailure{
+		location: softLocation(),
+		message:  softMessage(fmt.Sprintf(format, args...)),
+	})
+}
+
+func (c *softCollector) Helper() {}
+
+// softLocation returns the location of the assertion that failed, which is the
+// first caller of Errorf outside of the assert package.
+func softLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/stretchr/testify/") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+var (
+	assertLabel        = regexp.MustCompile(`^\t([^\t:]+):\s*\t(.*)$`)
+	assertContinuation = regexp.MustCompile(`^\t *\t`)
+)
+
+// softMessage returns the content of the failure message of an assertion,
+// without the labels for its location and test name which are redundant with
+// the report of the soft assertions.
+func softMessage(message string) string {
+	var b strings.Builder
+	keep := true
+	for _, line := range strings.Split(strings.TrimLeft(message, "\n"), "\n") {
+		if m := assertLabel.FindStringSubmatch(line); m != nil {
+			keep = m[1] != "Error Trace" && m[1] != "Test"
+			if !keep {
+				continue
+			}
+			if m[1] == "Error" {
+				line = m[2]
+			} else {
+				line = m[1] + ": " + m[2]
+			}
+		} else if !keep {
+			continue
+		} else {
+			line = assertContinuation.ReplaceAllString(line, "")
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// Soft runs f with assertions whose failures are collected rather than
+// reported right away. Once f returns, all the failures are reported together
+// as a single error listing the location of each failed assertion. It returns
+// whether all the assertions passed.
+//
+//	s.Soft(func(a *assert.Assertions) {
+//		a.Equal("gopher", user.Name)
+//		a.Equal(42, user.Age)
+//	})
+func (s *Suite[T, G]) Soft(f func(a *assert.Assertions)) bool {
+	s.tb.Helper()
+
+	c := &softCollector{}
+	f(assert.New(c))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.failures) == 0 {
+		return true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "soft assertions failed with %d failure(s):", len(c.failures))
+	for _, failure := range c.failures {
+		fmt.Fprintf(&b, "\n  %s: %s", failure.location, strings.ReplaceAll(failure.message, "\n", "\n    "))
+	}
+	s.tb.Error(b.String())
+	return false
+}
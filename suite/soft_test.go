@@ -0,0 +1,57 @@
+package suite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// softSuite is intended to test that the failures of soft assertions are
+// reported together, with the location of each of them.
+type softSuite struct {
+	*suite.Suite[softSuite, softSuiteGlobalData]
+}
+
+type softSuiteGlobalData struct{}
+
+func (s *softSuite) TestPass() {
+	s.True(s.Soft(func(a *assert.Assertions) {
+		a.Equal(1, 1)
+		a.True(true)
+	}))
+}
+
+func (s *softSuite) TestFail() {
+	ok := s.Soft(func(a *assert.Assertions) {
+		a.Equal(1, 2, "first")
+		a.True(true)
+		a.Contains("gopher", "x", "second")
+	})
+	s.Log("after soft assertions")
+	s.False(ok)
+}
+
+func TestSuiteSoft(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/softSuite",
+		F: func(t *testing.T) {
+			suite.Run[softSuite, softSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: TestSuiteSoft/softSuite/TestFail")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteSoft/softSuite/TestPass")
+	assert.Contains(t, output, "soft assertions failed with 2 failure(s):\n")
+	assert.Regexp(t, `soft_test\.go:\d+: Not equal: \n\s+expected: 1\n\s+actual  : 2\n\s+Messages: first\n`, output)
+	assert.Regexp(t, `soft_test\.go:\d+: "gopher" does not contain "x"\n\s+Messages: second\n`, output)
+	assert.NotContains(t, output, "Error Trace")
+	assert.Contains(t, output, "after soft assertions")
+}
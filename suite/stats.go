@@ -6,6 +6,11 @@ import "time"
 type SuiteInformation struct {
 	Start, End time.Time
 	TestStats  map[string]*TestInformation
+	Skip       *SkipInfo // set if the whole suite was skipped
+
+	// ObsoleteSnapshots lists the snapshots of the suite that weren't taken
+	// again by their test, or whose test doesn't exist anymore.
+	ObsoleteSnapshots []string
 }
 
 // TestInformation stores information about the execution of each test.
@@ -13,6 +18,24 @@ type TestInformation struct {
 	TestName   string
 	Start, End time.Time
 	Passed     bool
+	Skipped    bool
+	Skip       *SkipInfo // set if the reason for skipping the test is known
+
+	// Output is the output of the test and its subtests, if the suite
+	// captures it (see CaptureOutput). Each line is prefixed with the name
+	// of the test or subtest that wrote it.
+	Output string
+}
+
+// SkipInfo stores the reason for skipping a suite or a test.
+type SkipInfo struct {
+	Reason string
+
+	// Condition is the name of the method that skipped the suite or test,
+	// e.g. "SkipIfShort", and Value is what the condition was checked
+	// against, e.g. the name of the missing environment variable.
+	Condition string
+	Value     string
 }
 
 func newSuiteInformation() *SuiteInformation {
@@ -35,6 +58,15 @@ func (s SuiteInformation) end(testName string, passed bool) {
 	s.TestStats[testName].Passed = passed
 }
 
+func (s SuiteInformation) skip(testName string, skip *SkipInfo) {
+	s.TestStats[testName].Skipped = true
+	s.TestStats[testName].Skip = skip
+}
+
+func (s SuiteInformation) output(testName string, output string) {
+	s.TestStats[testName].Output = output
+}
+
 func (s SuiteInformation) Passed() bool {
 	for _, stats := range s.TestStats {
 		if !stats.Passed {
@@ -1,12 +1,16 @@
 package suite
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"reflect"
 	"regexp"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,101 +24,214 @@ var (
 	excludeMethod = flag.String("testify.x", "", "regular expression to exclude tests of the testify suite to run")
 )
 
+// ErrSkip can be wrapped by the errors returned from the error-returning
+// setup hooks to skip, rather than fail, the suite, test or subtest.
+//
+//	return fmt.Errorf("%w: docker is not available", suite.ErrSkip)
+var ErrSkip = errors.New("skipped")
+
 type Suite[T any, G any] struct {
 	*assert.Assertions
 	require  *require.Assertions
 	testingT *testing.T
-	suite    *T // user-defined test suite
-	g        *G // global data for the suite
-	parent   *T // for subtests, the parent suite instance
-}
-
-// T retrieves the current *testing.T context.
+	testingB *testing.B
+	testingF *testing.F
+	tb       testing.TB // where failures are reported: testingT, testingB, testingF, or a recorder in ExpectFail
+	suite    *T         // user-defined test suite
+	g        *G         // global data for the suite
+	parent   *T         // for subtests, the parent suite instance
+	phase    Phase      // the phase being executed
+	phaseMu  sync.Mutex // guards phase, which can be read from any goroutine
+	skip     *SkipInfo  // the reason for skipping the test, if known
+	info     TestInfo   // information about the current test
+	ctx      context.Context
+	children sync.Once // runs the BeforeChildren hook before the first subtest
+
+	snapshots     *snapshotRun // snapshots taken by the run of the suite
+	snapshotCount int          // number of snapshots taken by the current test
+
+	output *testOutput  // where the output of the test is captured, if it is
+	logs   *logRecorder // records logged by the test with Slog
+
+	ignoredGoroutines *ignoredGoroutines // functions of the goroutines that aren't leaked, if any
+	parallel          bool               // whether the test called Parallel
+	tempDir           string             // the temporary directory of the suite checked by CheckResources, if any
+	exclusion         *exclusion         // for running the tests changing the state of the process exclusively
+	readOnly          *globalSnapshot    // the global data checked for modifications by the test, if any
+
+	teardown teardownErrors // errors reported by the teardown hooks
+}
+
+// T retrieves the current *testing.T context. It is not set when running
+// benchmarks and fuzz targets, see [Suite.TB].
 func (s *Suite[T, G]) T() *testing.T {
 	return s.testingT
 }
 
+// TB retrieves what the current test, benchmark or fuzz target reports to:
+// the current *testing.T, *testing.B or *testing.F, or the testing.TB passed
+// to [New]. Helpers meant to be shared between tests and benchmarks should
+// use it, or the methods of the suite, rather than [Suite.T].
+func (s *Suite[T, G]) TB() testing.TB {
+	return s.tb
+}
+
+// B retrieves the current *testing.B context. It is only set when running
+// benchmarks, see [RunBenchmarks].
+func (s *Suite[T, G]) B() *testing.B {
+	return s.testingB
+}
+
+// F retrieves the current *testing.F context. It is only set for the fuzz
+// targets, see [RunFuzz].
+func (s *Suite[T, G]) F() *testing.F {
+	return s.testingF
+}
+
 // G retrieves the global data for the suite.
 func (s *Suite[T, G]) G() *G {
 	return s.g
 }
 
 func (s *Suite[T, G]) Cleanup(f func()) {
-	s.T().Cleanup(f)
+	s.tb.Cleanup(f)
 }
 
 func (s *Suite[T, G]) Failed() bool {
-	return s.T().Failed()
+	return s.tb.Failed()
 }
 
 func (s *Suite[T, G]) Fatal(args ...any) {
-	s.T().Fatal(args...)
+	s.tb.Fatal(args...)
 }
 
 func (s *Suite[T, G]) Fatalf(format string, args ...any) {
-	s.T().Fatalf(format, args...)
+	s.tb.Fatalf(format, args...)
 }
 
 func (s *Suite[T, G]) Helper() {
-	s.T().Helper()
+	s.tb.Helper()
 }
 
 func (s *Suite[T, G]) Log(args ...any) {
-	s.T().Log(args...)
+	s.tb.Log(args...)
 }
 
 func (s *Suite[T, G]) Logf(format string, args ...any) {
-	s.T().Logf(format, args...)
+	s.tb.Logf(format, args...)
 }
 
 func (s *Suite[T, G]) Name() string {
-	return s.T().Name()
+	return s.tb.Name()
 }
 
 func (s *Suite[T, G]) Skip(args ...any) {
-	s.T().Skip(args...)
+	s.tb.Helper()
+	s.skip = &SkipInfo{Reason: strings.TrimSuffix(fmt.Sprintln(args...), "\n")}
+	s.tb.Skip(args...)
 }
 
 func (s *Suite[T, G]) SkipNow() {
-	s.T().SkipNow()
+	s.tb.SkipNow()
 }
 
 func (s *Suite[T, G]) Skipf(format string, args ...any) {
-	s.T().Skipf(format, args...)
+	s.tb.Helper()
+	s.skip = &SkipInfo{Reason: fmt.Sprintf(format, args...)}
+	s.tb.Skipf(format, args...)
 }
 
 func (s *Suite[T, G]) Skipped() bool {
-	return s.T().Skipped()
+	return s.tb.Skipped()
 }
 
 func (s *Suite[T, G]) TempDir() string {
-	return s.T().TempDir()
+	return s.tb.TempDir()
 }
 
 func (s *Suite[T, G]) Deadline() (deadline time.Time, ok bool) {
-	return s.T().Deadline()
-}
-
-func (s *Suite[T, G]) Setenv(key, value string) {
-	s.T().Setenv(key, value)
-}
-
-func (s *Suite[T, G]) Parallel() {
-	s.T().Parallel()
+	if t, ok := s.tb.(interface{ Deadline() (time.Time, bool) }); ok {
+		return t.Deadline()
+	}
+	return time.Time{}, false
 }
 
+// Parent returns the suite instance of the parent test. For tests, this is
+// the suite instance used to setup the suite and for subtests, it is the
+// instance of the test (or subtest) that started them. Since it embeds
+// [Suite], it gives access to the per-test data of the parent as well as
+// to its name, [Suite.T], [Suite.Context], [Suite.Cleanup] and so on.
 func (s *Suite[T, G]) Parent() *T {
 	return s.parent
 }
 
+// Context returns a context that is canceled once the current test, all of
+// its subtests and all of its cleanup functions have finished, or shortly
+// before the test deadline set by the -timeout flag is reached, leaving the
+// test some time to report why it didn't finish in time.
+func (s *Suite[T, G]) Context() context.Context {
+	return s.ctx
+}
+
 // setT sets the current *testing.T context.
 func (s *Suite[T, G]) setT(testingT *testing.T) {
 	if s.T() != nil {
 		panic("Suite.testingT already set, can't overwrite")
 	}
 	s.testingT = testingT
-	s.Assertions = assert.New(testingT)
-	s.require = require.New(testingT)
+	s.setTB(testingT)
+}
+
+// setB sets the current *testing.B context.
+func (s *Suite[T, G]) setB(testingB *testing.B) {
+	if s.B() != nil {
+		panic("Suite.testingB already set, can't overwrite")
+	}
+	s.testingB = testingB
+	s.setTB(testingB)
+}
+
+// setF sets the current *testing.F context.
+func (s *Suite[T, G]) setF(testingF *testing.F) {
+	if s.F() != nil {
+		panic("Suite.testingF already set, can't overwrite")
+	}
+	s.testingF = testingF
+	s.setTB(testingF)
+}
+
+// setTB sets what the failures are reported to, along with the assertions and
+// the context reporting to it.
+func (s *Suite[T, G]) setTB(tb testing.TB) {
+	s.tb = tb
+	s.Assertions = assert.New(tb)
+	s.require = require.New(tb)
+	s.logs = &logRecorder{}
+
+	// The context is canceled by the first registered cleanup function, which
+	// is the last one to run, or shortly before the test deadline, so that
+	// what waits on it can still report a failure before the test binary
+	// panics because of the -timeout flag.
+	var cancel context.CancelFunc
+	if t, ok := tb.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := t.Deadline(); ok {
+			s.ctx, cancel = context.WithDeadline(context.Background(), deadline.Add(-deadlineGracePeriod(deadline)))
+		}
+	}
+	if s.ctx == nil {
+		s.ctx, cancel = context.WithCancel(context.Background())
+	}
+	tb.Cleanup(cancel)
+}
+
+// deadlineGracePeriod returns how long before the test deadline the context of
+// the test is canceled: 5% of the time left, and at least 100ms.
+func deadlineGracePeriod(deadline time.Time) time.Duration {
+	gracePeriod := time.Until(deadline) / 20
+	if gracePeriod < 100*time.Millisecond {
+		gracePeriod = 100 * time.Millisecond
+	}
+	return gracePeriod
 }
 
 // setG sets the global data for the suite.
@@ -143,7 +260,7 @@ func (s *Suite[T, G]) setP(suite *T) {
 
 // Require returns a require context for suite.
 func (s *Suite[T, G]) Require() *require.Assertions {
-	if s.T() == nil {
+	if s.tb == nil {
 		panic("Suite.testingT not set, can't get Require object")
 	}
 	return s.require
@@ -151,7 +268,7 @@ func (s *Suite[T, G]) Require() *require.Assertions {
 
 // Assert returns an assert context for suite.
 func (s *Suite[T, G]) Assert() *assert.Assertions {
-	if s.T() == nil {
+	if s.tb == nil {
 		panic("Suite.testingT not set, can't get Assert object")
 	}
 	return s.Assertions
@@ -166,37 +283,108 @@ func recoverAndFailOnPanic[T any, G any](s *Suite[T, G]) {
 func failOnPanic[T any, G any](s *Suite[T, G], r any) {
 	s.Helper()
 	if r != nil {
-		s.T().Errorf("test panicked: %v\n%s", r, debug.Stack())
-		s.T().FailNow()
+		s.tb.Errorf("test panicked: %v\n%s", r, debug.Stack())
+		s.tb.FailNow()
 	}
 }
 
+// failOnSetupError fails the current test, or skips it if err wraps [ErrSkip],
+// when an error-returning setup hook returns an error. The skip is recorded
+// with the name of the hook as its condition, e.g. "SetupTestE".
+func (s *Suite[T, G]) failOnSetupError(phase Phase, err error) {
+	s.tb.Helper()
+	if err == nil {
+		return
+	}
+	if errors.Is(err, ErrSkip) {
+		s.skipWith(string(phase)+"E", "", fmt.Sprintf("%s: %v", phase, err))
+	}
+	s.tb.Fatalf("%s: %v", phase, err)
+}
+
 // Run provides suite functionality around golang subtests. It should be
 // called in place of t.Run(name, func(t *testing.T)) in test suite code.
 // The passed-in func will be executed as a subtest with a fresh instance of t.
 // Provides compatibility with go test pkg -run TestSuite/TestName/SubTestName.
 func (s *Suite[T, G]) Run(name string, subtest func(suite *T)) bool {
+	if s.T() == nil {
+		s.tb.Helper()
+		s.tb.Fatalf("Run requires a *testing.T, use RunB in benchmarks")
+		return false
+	}
+
+	// [BeforeChildren] runs once, before the first subtest is started, and
+	// [AfterChildren] runs once all the subtests are done.
+	s.children.Do(func() {
+		if beforeChildren, ok := any(s.suite).(BeforeChildren); ok {
+			beforeChildren.BeforeChildren()
+		}
+		if afterChildren, ok := any(s.suite).(AfterChildren); ok {
+			s.Cleanup(func() {
+				s.runTeardown(PhaseAfterChildren, func() error {
+					afterChildren.AfterChildren()
+					return nil
+				})
+			})
+		}
+	})
+
 	return s.T().Run(name, func(testingT *testing.T) {
 		// Each subtest gets a fresh instance of Suite.
 		// The global data is passed through to all new instances.
 		newS := &Suite[T, G]{}
-		newSuite := new(T)
 		newS.setT(testingT)
 		newS.setG(s.G())
-		newS.setS(newSuite)
 		newS.setP(s.suite)
+		newS.info = newTestInfo(s.info, testingT)
+		newS.snapshots = s.snapshots
+		newS.exclusion = newExclusion(s.exclusion)
+		defer newS.exclusion.bodyDone(newS.Cleanup)
 
 		// This catches panics in the subtest setup and fails the test.
 		defer recoverAndFailOnPanic(newS)
 
+		// Unless the suite opts into inheriting the per-test data of the parent,
+		// the subtest starts with a zero value of it.
+		newSuite := s.newChild()
+		newS.setS(newSuite)
+
 		if err := setField(newS.suite, "Suite", newS); err != nil {
 			panic("make sure that your test suite embeds `*suite.Suite`")
 		}
 
+		// The other tests are only allowed to run in parallel with the subtest
+		// once it and all of its cleanup functions are done.
+		newS.Cleanup(newS.exclusion.release)
+
+		// The output of the subtest is captured along with the output of its
+		// parent, until all of its cleanup functions are done.
+		newS.captureOutput(s.output)
+
+		// The snapshots of a subtest can only be obsolete if it passed, and
+		// are kept if it was skipped.
+		newS.Cleanup(func() {
+			switch {
+			case newS.Skipped():
+				s.snapshots.skip(newS.Name())
+			case !newS.Failed():
+				s.snapshots.pass(newS.Name())
+			}
+		})
+
+		// The errors reported by the teardown hooks are reported together
+		// once all of them have run.
+		newS.Cleanup(func() { newS.teardown.report(newS.tb) })
+
 		// Setup the subtest.
+		newS.setPhase(PhaseSetupSubTest)
 		if setupSubTest, ok := any(newSuite).(SetupSubTest); ok {
 			setupSubTest.SetupSubTest()
 		}
+		var setupErr error
+		if setupSubTestE, ok := any(newSuite).(SetupSubTestE); ok {
+			setupErr = setupSubTestE.SetupSubTestE()
+		}
 
 		// [T.Cleanup], unlike defer, ensures that the teardown method is executed after all
 		// the subtests (of this subtest) are done, even in the case of parallel subtests.
@@ -206,11 +394,45 @@ func (s *Suite[T, G]) Run(name string, subtest func(suite *T)) bool {
 		// [SetupSubTest].
 		if tearDownSubTest, ok := any(newSuite).(TearDownSubTest); ok {
 			newS.Cleanup(func() {
-				defer recoverAndFailOnPanic(newS)
-				tearDownSubTest.TearDownSubTest()
+				newS.runTeardown(PhaseTearDownSubTest, func() error {
+					tearDownSubTest.TearDownSubTest()
+					return nil
+				})
+			})
+		}
+		if tearDownSubTestE, ok := any(newSuite).(TearDownSubTestE); ok {
+			newS.Cleanup(func() {
+				newS.runTeardown(PhaseTearDownSubTest, tearDownSubTestE.TearDownSubTestE)
 			})
 		}
 
+		// A setup error is only acted upon after the teardown hooks have been
+		// registered so that whatever was set up before the error is torn down.
+		newS.failOnSetupError(PhaseSetupSubTest, setupErr)
+		newS.setPhase(PhaseTest)
+
+		// The order of calls are: SetupSubTest -> BeforeSubTest -> SubTest ->
+		// AfterSubTest -> TearDownSubTest
+		info := newS.Info()
+		testName, subTestName := info.Path[0], strings.Join(info.Path[1:], "/")
+		if beforeSubTest, ok := any(newSuite).(BeforeSubTest); ok {
+			beforeSubTest.BeforeSubTest(info.SuiteName, testName, subTestName)
+		}
+
+		// We register [AfterSubTest] after calling [BeforeSubTest] because we
+		// want [AfterSubTest] to run before any cleanup functions registered
+		// within [BeforeSubTest].
+		if afterSubTest, ok := any(newSuite).(AfterSubTest); ok {
+			newS.Cleanup(func() {
+				newS.runTeardown(PhaseAfterSubTest, func() error {
+					afterSubTest.AfterSubTest(info.SuiteName, testName, subTestName, !newS.Failed())
+					return nil
+				})
+			})
+		}
+
+		newS.runEachHooks()
+
 		// Call the subtest function with the new instance of the suite.
 		// This new instance of suite will have its own testing.T context.
 		// as well as per-test data. Global data will be shared.
@@ -218,6 +440,90 @@ func (s *Suite[T, G]) Run(name string, subtest func(suite *T)) bool {
 	})
 }
 
+// newChild returns the user-defined test suite for a new subtest of s. It is a
+// copy of the user-defined test suite of s if the suite implements
+// [CloneSubTest] or [InheritSubTest], and a zero value otherwise.
+func (s *Suite[T, G]) newChild() *T {
+	if cloneSubTest, ok := any(s.suite).(CloneSubTest[T]); ok {
+		newSuite := cloneSubTest.Clone()
+		if newSuite == nil {
+			panic("Clone returned nil")
+		}
+		return newSuite
+	}
+
+	newSuite := new(T)
+	if inheritSubTest, ok := any(s.suite).(InheritSubTest); ok && inheritSubTest.InheritSubTest() {
+		*newSuite = *s.suite
+	}
+	return newSuite
+}
+
+// newView returns a new instance of Suite for the same test as s, reporting to
+// tb instead, e.g. the recorder of [Suite.ExpectFail]. Like the instance of a
+// test, it gets its own assertions and context from setTB, and its teardown
+// errors are reported to tb. It shares the rest of the state of the test with
+// s, including the records logged with Slog.
+func (s *Suite[T, G]) newView(tb testing.TB) *Suite[T, G] {
+	if s.ignoredGoroutines == nil {
+		s.ignoredGoroutines = &ignoredGoroutines{}
+	}
+
+	newS := &Suite[T, G]{
+		testingT:          s.testingT,
+		testingB:          s.testingB,
+		testingF:          s.testingF,
+		phase:             s.currentPhase(),
+		info:              s.info,
+		snapshots:         s.snapshots,
+		snapshotCount:     s.snapshotCount,
+		output:            s.output,
+		ignoredGoroutines: s.ignoredGoroutines,
+		parallel:          s.parallel,
+		tempDir:           s.tempDir,
+		exclusion:         s.exclusion,
+		readOnly:          s.readOnly,
+	}
+	newS.setTB(tb)
+	newS.logs = s.logs
+	newS.setG(s.G())
+	newS.setP(s.parent)
+	newS.Cleanup(func() { newS.teardown.report(newS.tb) })
+	return newS
+}
+
+// New returns an instance of the suite T reporting to tb, with g as its global
+// data, or a zero value of it if g is nil. None of the hooks of the suite are
+// run. This is meant to use the helpers and assertions of a suite outside of
+// [Run], e.g. from custom runners or with a fake testing.TB in self-tests.
+func New[T any, G any](tb testing.TB, g *G) *T {
+	if g == nil {
+		g = new(G)
+	}
+
+	s := &Suite[T, G]{}
+	suite := new(T)
+	switch tb := tb.(type) {
+	case *testing.T:
+		s.setT(tb)
+	case *testing.B:
+		s.setB(tb)
+	case *testing.F:
+		s.setF(tb)
+	default:
+		s.setTB(tb)
+	}
+	s.setG(g)
+	s.setS(suite)
+	s.setP(nil)
+	s.info = TestInfo{SuiteName: reflect.TypeOf(suite).Elem().Name(), Name: tb.Name()}
+
+	if err := setField(s.suite, "Suite", s); err != nil {
+		panic("make sure that your test suite embeds `*suite.Suite`")
+	}
+	return suite
+}
+
 // Run runs all of the tests attached to a suite.
 func Run[T any, G any](testingT *testing.T) {
 	flag.Parse()
@@ -238,18 +544,23 @@ func Run[T any, G any](testingT *testing.T) {
 
 	methodFinder := reflect.TypeOf(suite)
 	suiteName := methodFinder.Elem().Name()
+	s.info = TestInfo{SuiteName: suiteName, Name: testingT.Name()}
+	s.exclusion = newExclusion(nil)
 
 	// Iterate over all the methods of the test suite and prepare the list of tests to run.
+	// Like in a package, the examples are run after the tests.
 	var methods []reflect.Method
-	f, err := methodFilter()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s\n", err)
-		os.Exit(1)
-	}
-	for i := 0; i < methodFinder.NumMethod(); i++ {
-		method := methodFinder.Method(i)
-		if f(method.Name) {
-			methods = append(methods, method)
+	for _, prefix := range []string{"Test", "Example"} {
+		f, err := methodFilter(prefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		for i := 0; i < methodFinder.NumMethod(); i++ {
+			method := methodFinder.Method(i)
+			if f(method.Name) {
+				methods = append(methods, method)
+			}
 		}
 	}
 
@@ -258,6 +569,10 @@ func Run[T any, G any](testingT *testing.T) {
 		return
 	}
 
+	// The errors reported by the teardown hooks of the suite (and the stats
+	// handler) are reported together once all of them have run.
+	s.Cleanup(func() { s.teardown.report(s.tb) })
+
 	// Setup stats.
 	var stats *SuiteInformation
 	if _, ok := any(suite).(WithStats); ok {
@@ -268,10 +583,12 @@ func Run[T any, G any](testingT *testing.T) {
 	// tests in the suite are done, even in the case of parallel tests.
 	if stats != nil {
 		s.Cleanup(func() {
-			defer recoverAndFailOnPanic(s)
 			stats.End = time.Now()
 			if suiteWithStats, ok := any(suite).(WithStats); ok {
-				suiteWithStats.HandleStats(suiteName, stats)
+				s.runTeardown(PhaseHandleStats, func() error {
+					suiteWithStats.HandleStats(suiteName, stats)
+					return nil
+				})
 			}
 		})
 
@@ -279,21 +596,88 @@ func Run[T any, G any](testingT *testing.T) {
 		stats.Start = time.Now()
 	}
 
-	// Setup the suite.
-	if setupAllSuite, ok := any(suite).(SetupAllSuite); ok {
-		setupAllSuite.SetupSuite()
+	// The snapshots that are obsolete can only be found once all the tests in
+	// the suite are done. This is registered after the stats so that they are
+	// part of them.
+	testMethods := make(map[string]bool)
+	for i := 0; i < methodFinder.NumMethod(); i++ {
+		testMethods[methodFinder.Method(i).Name] = true
 	}
-
-	// [T.Cleanup], unlike defer, ensures that the suite teardown method is executed only after
-	// all the tests in the suite are done, even in the case of parallel tests.
-	//
-	// We register [TearDownAllSuite] after calling [SetupAllSuite] because we want
-	// [TearDownAllSuite] to run before any cleanup functions registered within [SetupAllSuite].
-	if tearDownAllSuite, ok := any(suite).(TearDownAllSuite); ok {
-		s.Cleanup(func() {
-			defer recoverAndFailOnPanic(s)
-			tearDownAllSuite.TearDownSuite()
+	s.snapshots = newSnapshotRun(suiteName, testingT.Name(), testMethods)
+	s.Cleanup(func() {
+		s.runTeardown(PhaseSnapshots, func() error {
+			obsolete, err := s.snapshots.finish()
+			if len(obsolete) > 0 {
+				if stats != nil {
+					stats.ObsoleteSnapshots = obsolete
+				}
+				if *update {
+					s.T().Logf("removed %d obsolete snapshot(s):\n  %s", len(obsolete), strings.Join(obsolete, "\n  "))
+				} else {
+					s.T().Logf("found %d obsolete snapshot(s), run the tests with -testify.update to remove them:\n  %s",
+						len(obsolete), strings.Join(obsolete, "\n  "))
+				}
+			}
+			return err
 		})
+	})
+
+	var tags map[string][]string
+	if withTags, ok := any(suite).(WithTags); ok {
+		tags = withTags.Tags()
+	}
+
+	// The suite can be skipped before it is even setup.
+	var skip *SkipInfo
+	if shouldSkipSuite, ok := any(suite).(ShouldSkipSuite); ok {
+		if ok, reason := shouldSkipSuite.ShouldSkip(); ok {
+			skip = &SkipInfo{Reason: reason, Condition: "ShouldSkip"}
+		}
+	}
+
+	if skip == nil {
+		// The temporary files left behind by the tests are looked for in a
+		// temporary directory of the suite.
+		s.useSuiteTempDir()
+
+		// Setup the suite.
+		var setupErr error
+		skip, setupErr = s.setupSuite()
+
+		s.registerTearDownSuite()
+
+		// A setup error is only acted upon after the teardown hooks have been
+		// registered so that whatever was set up before the error is torn down.
+		s.failOnSetupError(PhaseSetupSuite, setupErr)
+	}
+
+	// When the suite is skipped, each of its tests is still reported (as skipped)
+	// but none of the per-test hooks are run.
+	if skip != nil {
+		if stats != nil {
+			stats.Skip = skip
+		}
+		for _, method := range methods {
+			method := method
+			testingT.Run(method.Name, func(testingT *testing.T) {
+				if stats != nil {
+					stats.start(method.Name)
+					stats.end(method.Name, true)
+					stats.skip(method.Name, skip)
+				}
+				testingT.Skip(skip.Reason)
+			})
+		}
+		testingT.Skip(skip.Reason)
+	}
+
+	// The global data is snapshotted once it has been setup so that each test
+	// can be checked not to modify it.
+	var readOnly *globalSnapshot
+	if freezeGlobal, ok := any(suite).(FreezeGlobal); ok && freezeGlobal.FreezeG() {
+		readOnly = newGlobalSnapshot(s.tb, s.G(), true)
+	} else if readOnlyGlobal, ok := any(suite).(ReadOnlyGlobal); ok && readOnlyGlobal.ReadOnlyG() {
+		readOnly = newGlobalSnapshot(s.tb, s.G(), false)
 	}
 
 	// Each method of the test suite is executed as a subtest of the suite.
@@ -312,6 +696,11 @@ func Run[T any, G any](testingT *testing.T) {
 				newS.setG(s.G())
 				newS.setS(newSuite)
 				newS.setP(s.suite)
+				newS.info = newTestInfo(s.info, testingT)
+				newS.info.Tags = tags[method.Name]
+				newS.snapshots = s.snapshots
+				newS.exclusion = newExclusion(s.exclusion)
+				defer newS.exclusion.bodyDone(newS.Cleanup)
 
 				// This catches panics in the test setup and fails the test.
 				defer recoverAndFailOnPanic(newS)
@@ -320,31 +709,63 @@ func Run[T any, G any](testingT *testing.T) {
 					panic("make sure that your test suite embeds `*suite.Suite`")
 				}
 
+				// The other tests are only allowed to run in parallel with the test
+				// once it and all of its cleanup functions are done.
+				newS.Cleanup(newS.exclusion.release)
+
 				// [T.Cleanup], unlike defer, ensures that the stats are updated
 				// only after all the sub-tests of this test are done, even in the
 				// case of parallel tests.
 				if stats != nil {
-					newS.Cleanup(func() { stats.end(method.Name, !newS.Failed()) })
+					newS.Cleanup(func() {
+						stats.end(method.Name, !newS.Failed())
+						if newS.Skipped() {
+							stats.skip(method.Name, newS.skip)
+						}
+						if newS.output != nil {
+							stats.output(method.Name, newS.output.captured.String())
+						}
+					})
 
 					// Start the stats collection.
 					stats.start(method.Name)
 				}
 
-				// The order of calls are: SetupTest -> BeforeTest -> Test ->
-				// AfterTest -> TearDownTest
-				if setupTestSuite, ok := any(newSuite).(SetupTestSuite); ok {
-					setupTestSuite.SetupTest()
+				// The output of the test is captured until all the other cleanup
+				// functions are done.
+				newS.captureOutput(nil)
+
+				// The goroutines started by the test, including by its setup
+				// hooks, are checked once all the other cleanup functions are done.
+				newS.checkGoroutines(s)
+
+				// The state of the process is checked as well, for the resources
+				// leaked by the test.
+				newS.checkResources(s)
+
+				// The snapshots of a test can only be obsolete if it passed.
+				newS.Cleanup(func() {
+					if !newS.Failed() && !newS.Skipped() {
+						s.snapshots.pass(newS.Name())
+					}
+				})
+
+				// The errors reported by the teardown hooks are reported together
+				// once all of them have run. This is registered after the stats so
+				// that the stats see the test as failed.
+				newS.Cleanup(func() { newS.teardown.report(newS.tb) })
+
+				// The global data is checked once the test and all of its cleanup
+				// functions are done.
+				if readOnly != nil {
+					newS.readOnly = readOnly
+					readOnly.start(method.Name)
+					newS.Cleanup(func() { newS.failIfModified(readOnly) })
 				}
 
-				// We register [TearDownTestSuite] after calling [SetupTestSuite]
-				// because we want [TearDownTestSuite] to run before any cleanup
-				// functions registered within [SetupTestSuite].
-				if tearDownTestSuite, ok := any(newSuite).(TearDownTestSuite); ok {
-					newS.Cleanup(func() {
-						defer recoverAndFailOnPanic(newS)
-						tearDownTestSuite.TearDownTest()
-					})
-				}
+				// The order of calls are: SetupTest -> BeforeTest -> Test ->
+				// AfterTest -> TearDownTest
+				newS.setupTest()
 
 				if beforeTestSuite, ok := any(newSuite).(BeforeTest); ok {
 					beforeTestSuite.BeforeTest(methodFinder.Elem().Name(), method.Name)
@@ -355,11 +776,26 @@ func Run[T any, G any](testingT *testing.T) {
 				// within [BeforeTest].
 				if afterTestSuite, ok := any(newSuite).(AfterTest); ok {
 					newS.Cleanup(func() {
-						defer recoverAndFailOnPanic(newS)
-						afterTestSuite.AfterTest(suiteName, method.Name)
+						newS.runTeardown(PhaseAfterTest, func() error {
+							afterTestSuite.AfterTest(suiteName, method.Name)
+							return nil
+						})
+					})
+				}
+				if afterTestSuiteE, ok := any(newSuite).(AfterTestE); ok {
+					newS.Cleanup(func() {
+						newS.runTeardown(PhaseAfterTest, func() error {
+							return afterTestSuiteE.AfterTestE(suiteName, method.Name)
+						})
 					})
 				}
 
+				newS.runEachHooks()
+
+				if strings.HasPrefix(method.Name, "Example") {
+					newS.runExample(method)
+					return
+				}
 				method.Func.Call([]reflect.Value{reflect.ValueOf(newSuite)})
 			},
 		}
@@ -373,13 +809,51 @@ func Run[T any, G any](testingT *testing.T) {
 	}
 }
 
-// Filtering methods according to set regular expression.
-func methodFilter() (func(name string) bool, error) {
+// setupTest runs the test setup hooks and registers the test teardown hooks.
+func (s *Suite[T, G]) setupTest() {
+	s.tb.Helper()
+
+	s.setPhase(PhaseSetupTest)
+	defer s.setPhase(PhaseTest)
+
+	if setupTestSuite, ok := any(s.suite).(SetupTestSuite); ok {
+		setupTestSuite.SetupTest()
+	}
+	var setupErr error
+	if setupTestSuiteE, ok := any(s.suite).(SetupTestSuiteE); ok {
+		setupErr = setupTestSuiteE.SetupTestE()
+	}
+
+	// We register [TearDownTestSuite] after calling [SetupTestSuite] because
+	// we want [TearDownTestSuite] to run before any cleanup functions
+	// registered within [SetupTestSuite].
+	if tearDownTestSuite, ok := any(s.suite).(TearDownTestSuite); ok {
+		s.Cleanup(func() {
+			s.runTeardown(PhaseTearDownTest, func() error {
+				tearDownTestSuite.TearDownTest()
+				return nil
+			})
+		})
+	}
+	if tearDownTestSuiteE, ok := any(s.suite).(TearDownTestSuiteE); ok {
+		s.Cleanup(func() {
+			s.runTeardown(PhaseTearDownTest, tearDownTestSuiteE.TearDownTestE)
+		})
+	}
+
+	// A setup error is only acted upon after the teardown hooks have been
+	// registered so that whatever was set up before the error is torn down.
+	s.failOnSetupError(PhaseSetupTest, setupErr)
+}
+
+// Filtering methods according to set regular expression. Only the methods
+// starting with prefix, e.g. "Test", are included.
+func methodFilter(prefix string) (func(name string) bool, error) {
 	var regexpTestFunctions, regexpInclude, regexpExclude *regexp.Regexp
 	var err error
 
-	// Regular expression to only include methods that start with "Test".
-	regexpTestFunctions = regexp.MustCompile("^Test")
+	// Regular expression to only include methods that start with prefix.
+	regexpTestFunctions = regexp.MustCompile("^" + regexp.QuoteMeta(prefix))
 
 	// Regular expression to include methods that match the regex set in the `testify.m` flag.
 	if testifyM := flag.Lookup("testify.m"); testifyM != nil && testifyM.Value != nil && testifyM.Value.String() != "" {
@@ -398,7 +872,7 @@ func methodFilter() (func(name string) bool, error) {
 	}
 
 	return func(name string) bool {
-		// Exclude methods that don't start with "Test".
+		// Exclude methods that don't start with prefix.
 		if ok := regexpTestFunctions.MatchString(name); !ok {
 			return false
 		}
@@ -0,0 +1,92 @@
+package suite_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// tbSuite is intended to test that a suite instance can report to any
+// testing.TB.
+type tbSuite struct {
+	*suite.Suite[tbSuite, tbSuiteGlobalData]
+}
+
+type tbSuiteGlobalData struct {
+	Name string
+}
+
+// checkName is a helper meant to be shared by tests and benchmarks.
+func (s *tbSuite) checkName(name string) {
+	s.TB().Helper()
+	s.Equal(s.G().Name, name)
+}
+
+// fakeTB is a testing.TB recording the failures reported to it.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Fatalf(format string, args ...any) {
+	tb.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func TestSuiteNew(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	s := suite.New[tbSuite](tb, &tbSuiteGlobalData{Name: "gopher"})
+	assert.Same(t, tb, s.TB())
+	assert.Nil(t, s.T())
+	assert.Equal(t, "tbSuite", s.Info().SuiteName)
+	assert.Equal(t, t.Name(), s.Name())
+
+	s.checkName("gopher")
+	assert.Empty(t, tb.errors)
+	s.checkName("gophers")
+	if assert.Len(t, tb.errors, 1) {
+		assert.Contains(t, tb.errors[0], `expected: "gopher"`)
+	}
+	assert.False(t, t.Failed())
+
+	s = suite.New[tbSuite, tbSuiteGlobalData](t, nil)
+	assert.Same(t, t, s.T())
+	assert.Same(t, t, s.TB())
+	assert.Empty(t, s.G().Name)
+}
+
+func TestSuiteNewWithoutT(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	s := suite.New[tbSuite](tb, &tbSuiteGlobalData{})
+
+	// The methods that need a *testing.T, or a *testing.B, fail instead of
+	// panicking.
+	for _, f := range []func(){
+		func() { s.Run("subtest", func(*tbSuite) {}) },
+		s.Parallel,
+		func() { s.RunB("sub-benchmark", func(*tbSuite) {}) },
+	} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			f()
+		}()
+		<-done
+	}
+	assert.Equal(t, []string{
+		"Run requires a *testing.T, use RunB in benchmarks",
+		"Parallel requires a *testing.T",
+		"RunB requires a *testing.B, use Run in tests",
+	}, tb.errors)
+	assert.False(t, t.Failed())
+}
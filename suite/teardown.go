@@ -0,0 +1,140 @@
+package suite
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Phase identifies a stage in the lifecycle of a suite, test or subtest.
+// It is named after the hook that runs during that stage.
+type Phase string
+
+const (
+	PhaseSetupSuite      Phase = "SetupSuite"
+	PhaseSetupTest       Phase = "SetupTest"
+	PhaseSetupSubTest    Phase = "SetupSubTest"
+	PhaseTest            Phase = "Test" // the body of a test or subtest
+	PhaseAfterChildren   Phase = "AfterChildren"
+	PhaseAfterEach       Phase = "AfterEach"
+	PhaseAfterSubTest    Phase = "AfterSubTest"
+	PhaseAfterTest       Phase = "AfterTest"
+	PhaseTearDownSubTest Phase = "TearDownSubTest"
+	PhaseTearDownTest    Phase = "TearDownTest"
+	PhaseTearDownSuite   Phase = "TearDownSuite"
+	PhaseSnapshots       Phase = "Snapshots"
+	PhaseHandleStats     Phase = "HandleStats"
+)
+
+// TeardownError is an error returned by (or a panic raised in) a teardown
+// hook. Phases lists every phase that reported the same error.
+type TeardownError struct {
+	Phases []Phase
+	Err    error
+}
+
+func (e *TeardownError) Error() string {
+	phases := make([]string, len(e.Phases))
+	for i, phase := range e.Phases {
+		phases[i] = string(phase)
+	}
+	return fmt.Sprintf("[%s] %v", strings.Join(phases, ", "), e.Err)
+}
+
+func (e *TeardownError) Unwrap() error {
+	return e.Err
+}
+
+// panicError wraps a value recovered from a panic in a teardown hook.
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// teardownErrors collects the errors reported by the teardown hooks of a
+// single suite instance so that they can be reported together once all the
+// teardown hooks have run. Errors with the same message are reported once.
+type teardownErrors struct {
+	mu   sync.Mutex
+	errs []*TeardownError
+}
+
+func (c *teardownErrors) add(phase Phase, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.errs {
+		if e.Err.Error() == err.Error() {
+			for _, p := range e.Phases {
+				if p == phase {
+					return
+				}
+			}
+			e.Phases = append(e.Phases, phase)
+			return
+		}
+	}
+	c.errs = append(c.errs, &TeardownError{Phases: []Phase{phase}, Err: err})
+}
+
+// report fails tb with all the collected errors, if any, and forgets them, so
+// that they are only reported once, e.g. by the rounds of a benchmark.
+func (c *teardownErrors) report(tb testing.TB) {
+	tb.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return
+	}
+	defer func() { c.errs = nil }()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "teardown failed with %d error(s):", len(c.errs))
+	for _, e := range c.errs {
+		fmt.Fprintf(&b, "\n  %s", e)
+		if p, ok := e.Err.(*panicError); ok {
+			b.WriteString("\n    " + strings.ReplaceAll(strings.TrimSpace(string(p.stack)), "\n", "\n    "))
+		}
+	}
+	tb.Error(b.String())
+}
+
+// runTeardown runs a teardown hook. Unlike [recoverAndFailOnPanic], errors and
+// panics are recorded rather than failing the test immediately, so that all the
+// failures of the remaining teardown hooks are reported together.
+func (s *Suite[T, G]) runTeardown(phase Phase, hook func() error) {
+	defer s.setPhase(s.setPhase(phase))
+	defer func() {
+		if r := recover(); r != nil {
+			s.teardown.add(phase, &panicError{value: r, stack: debug.Stack()})
+		}
+	}()
+
+	if err := hook(); err != nil {
+		s.teardown.add(phase, err)
+	}
+}
+
+// setPhase sets the phase being executed and returns the previous one.
+func (s *Suite[T, G]) setPhase(phase Phase) Phase {
+	s.phaseMu.Lock()
+	defer s.phaseMu.Unlock()
+	previous := s.phase
+	s.phase = phase
+	return previous
+}
+
+// currentPhase returns the phase being executed. It can be called from any
+// goroutine, e.g. by the handler of [Suite.Slog].
+func (s *Suite[T, G]) currentPhase() Phase {
+	s.phaseMu.Lock()
+	defer s.phaseMu.Unlock()
+	return s.phase
+}
@@ -0,0 +1,79 @@
+package suite_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/varunbpatil/testify/suite"
+)
+
+// teardownErrorsSuite is intended to test that the errors and panics of all
+// the teardown hooks are collected and reported together.
+type teardownErrorsSuite struct {
+	*suite.Suite[teardownErrorsSuite, teardownErrorsSuiteGlobalData]
+}
+
+type teardownErrorsSuiteGlobalData struct{}
+
+var (
+	errConnectionClosed = errors.New("connection closed")
+	teardownCallOrder   []string
+)
+
+func (s *teardownErrorsSuite) Test() {
+	teardownCallOrder = append(teardownCallOrder, "Test")
+}
+
+func (s *teardownErrorsSuite) AfterTestE(_, _ string) error {
+	teardownCallOrder = append(teardownCallOrder, "AfterTestE")
+	return errConnectionClosed
+}
+
+func (s *teardownErrorsSuite) TearDownTest() {
+	teardownCallOrder = append(teardownCallOrder, "TearDownTest")
+	panic("oops in tear down test")
+}
+
+func (s *teardownErrorsSuite) TearDownTestE() error {
+	teardownCallOrder = append(teardownCallOrder, "TearDownTestE")
+	return errConnectionClosed
+}
+
+func (s *teardownErrorsSuite) TearDownSuiteE() error {
+	teardownCallOrder = append(teardownCallOrder, "TearDownSuiteE")
+	return errors.New("failed to drop database")
+}
+
+func (s *teardownErrorsSuite) TearDownSuite() {
+	teardownCallOrder = append(teardownCallOrder, "TearDownSuite")
+}
+
+func TestSuiteTeardownErrors(t *testing.T) {
+	teardownCallOrder = nil
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/teardownErrorsSuite",
+		F: func(t *testing.T) {
+			suite.Run[teardownErrorsSuite, teardownErrorsSuiteGlobalData](t)
+		},
+	}})
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// A failing teardown hook doesn't prevent the remaining ones from running.
+	callOrderAssert(t, []string{
+		"Test", "AfterTestE", "TearDownTestE", "TearDownTest", "TearDownSuiteE", "TearDownSuite",
+	}, teardownCallOrder)
+
+	assert.Contains(t, output, "teardown failed with 2 error(s):")
+	assert.Contains(t, output, "[AfterTest, TearDownTest] connection closed")
+	assert.Contains(t, output, "[TearDownTest] panic: oops in tear down test")
+	assert.Contains(t, output, "teardown failed with 1 error(s):")
+	assert.Contains(t, output, "[TearDownSuite] failed to drop database")
+}
@@ -0,0 +1,18 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package suite
+
+import "syscall"
+
+// Umask sets the file mode creation mask of the process to mask, and restores
+// the previous one once the test and all of its cleanup functions registered
+// after calling Umask are done. It returns the previous mask. Like
+// [Suite.Setenv], it can be called from parallel tests, which then run
+// exclusively.
+func (s *Suite[T, G]) Umask(mask int) int {
+	s.Exclusive()
+
+	previous := syscall.Umask(mask)
+	s.Cleanup(func() { syscall.Umask(previous) })
+	return previous
+}